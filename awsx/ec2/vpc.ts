@@ -65,6 +65,9 @@ export interface ResolvedVpcEndpointSpec {
 export class Vpc extends schema.Vpc<VpcData> {
   constructor(name: string, args: schema.VpcArgs, opts: pulumi.ComponentResourceOptions = {}) {
     super(name, args, opts);
+    if (opts.urn) {
+      return; // Rehydrating, skip construction
+    }
 
     const data = pulumi.output(this.getData());
 
@@ -0,0 +1,117 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import { ManifestClient, resolveAttestationRefs } from "./attestations";
+
+const repositoryUrl = "123456789012.dkr.ecr.us-west-2.amazonaws.com/app";
+
+// A stand-in for the ECR API serving the given manifests by digest.
+function fakeClient(manifests: Record<string, object>) {
+  const requests: any[] = [];
+  const client: ManifestClient = {
+    batchGetImage: async (request) => {
+      requests.push(request);
+      return {
+        images: request.imageIds.map(({ imageDigest }) => ({
+          imageId: { imageDigest },
+          imageManifest: JSON.stringify(manifests[imageDigest]),
+        })),
+      };
+    },
+  };
+  return { client, requests };
+}
+
+function index(...attestationDigests: string[]) {
+  return {
+    manifests: [
+      { digest: "sha256:image", platform: { os: "linux", architecture: "amd64" } },
+      ...attestationDigests.map((digest) => ({
+        digest,
+        annotations: {
+          "vnd.docker.reference.digest": "sha256:image",
+          "vnd.docker.reference.type": "attestation-manifest",
+        },
+      })),
+    ],
+  };
+}
+
+function attestation(...predicateTypes: string[]) {
+  return {
+    layers: predicateTypes.map((type) => ({
+      annotations: { "in-toto.io/predicate-type": type },
+    })),
+  };
+}
+
+describe("resolveAttestationRefs", () => {
+  it("returns the attestation manifests of the pushed index", async () => {
+    const { client, requests } = fakeClient({
+      "sha256:index": index("sha256:attestation"),
+      "sha256:attestation": attestation(
+        "https://spdx.dev/Document",
+        "https://slsa.dev/provenance/v0.2",
+      ),
+    });
+
+    const refs = await resolveAttestationRefs(
+      repositoryUrl,
+      undefined,
+      ["sha256:index"],
+      { sbom: true, provenance: true },
+      client,
+    );
+
+    expect(refs).toEqual([`${repositoryUrl}@sha256:attestation`]);
+    expect(requests[0]).toMatchObject({
+      registryId: "123456789012",
+      repositoryName: "app",
+      imageIds: [{ imageDigest: "sha256:index" }],
+    });
+  });
+
+  it("fails if a requested attestation is missing", async () => {
+    const { client } = fakeClient({
+      "sha256:index": index("sha256:attestation"),
+      "sha256:attestation": attestation("https://slsa.dev/provenance/v0.2"),
+    });
+
+    await expect(
+      resolveAttestationRefs(
+        repositoryUrl,
+        undefined,
+        ["sha256:index"],
+        { sbom: true, provenance: true },
+        client,
+      ),
+    ).rejects.toThrow(
+      `The image ${repositoryUrl}@sha256:index was pushed without the requested sbom attestations`,
+    );
+  });
+
+  it("fails if the image was pushed without attestations", async () => {
+    const { client } = fakeClient({ "sha256:index": index() });
+
+    await expect(
+      resolveAttestationRefs(
+        repositoryUrl,
+        undefined,
+        ["sha256:index"],
+        { provenance: true },
+        client,
+      ),
+    ).rejects.toThrow("was pushed without the requested provenance attestations");
+  });
+});
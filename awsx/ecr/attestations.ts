@@ -0,0 +1,144 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import { BatchGetImageCommand } from "@aws-sdk/client-ecr";
+import * as pulumi from "@pulumi/pulumi";
+import * as schema from "../schema-types";
+import { parseRepositoryUrl } from "./immutableTags";
+import { ecrClient } from "./scanGate";
+
+/** The subset of the ECR BatchGetImage API used to read pushed manifests. */
+export interface ManifestClient {
+  batchGetImage(request: {
+    registryId: string;
+    repositoryName: string;
+    imageIds: { imageDigest: string }[];
+    acceptedMediaTypes: string[];
+  }): Promise<{
+    images?: { imageId?: { imageDigest?: string }; imageManifest?: string }[];
+    failures?: { imageId?: { imageDigest?: string }; failureReason?: string }[];
+  }>;
+}
+
+const manifestMediaTypes = [
+  "application/vnd.oci.image.index.v1+json",
+  "application/vnd.docker.distribution.manifest.list.v2+json",
+  "application/vnd.oci.image.manifest.v1+json",
+  "application/vnd.docker.distribution.manifest.v2+json",
+];
+
+// BuildKit marks the manifests of an index that hold attestations with this annotation, and
+// annotates their layers with the in-toto predicate type.
+const referenceTypeAnnotation = "vnd.docker.reference.type";
+const predicateTypeAnnotation = "in-toto.io/predicate-type";
+
+const predicateTypePrefixes: Record<keyof schema.ImageAttestationsInputs, string> = {
+  sbom: "https://spdx.dev/Document",
+  provenance: "https://slsa.dev/provenance/",
+};
+
+interface Manifest {
+  manifests?: { digest: string; annotations?: Record<string, string> }[];
+  layers?: { annotations?: Record<string, string> }[];
+}
+
+function defaultManifestClient(region: string | undefined): ManifestClient {
+  const ecr = ecrClient(region);
+  return {
+    batchGetImage: (request) => ecr.send(new BatchGetImageCommand(request)),
+  };
+}
+
+/**
+ * Returns references to the attestation manifests of the pushed images. Skipped during previews,
+ * as nothing has been pushed yet.
+ * @internal
+ */
+export function attestationRefs(
+  repositoryUrl: string,
+  registryId: string | undefined,
+  digests: pulumi.Input<string[]>,
+  attestations: pulumi.Unwrap<schema.ImageAttestationsInputs>,
+  client?: ManifestClient,
+): pulumi.Output<string[] | undefined> {
+  return pulumi.output(digests).apply(async (digests) => {
+    if (pulumi.runtime.isDryRun()) {
+      return undefined;
+    }
+    return resolveAttestationRefs(repositoryUrl, registryId, digests, attestations, client);
+  });
+}
+
+/**
+ * Reads the index pushed for each image digest and returns references to its attestation
+ * manifests. Fails if an index lacks one of the requested attestations, so that they can't go
+ * missing silently.
+ * @internal
+ */
+export async function resolveAttestationRefs(
+  repositoryUrl: string,
+  registryId: string | undefined,
+  digests: string[],
+  attestations: pulumi.Unwrap<schema.ImageAttestationsInputs>,
+  client?: ManifestClient,
+): Promise<string[]> {
+  const parsed = parseRepositoryUrl(repositoryUrl);
+  const manifestClient = client ?? defaultManifestClient(parsed.region);
+  const getManifests = async (imageDigests: string[]): Promise<Manifest[]> => {
+    const response = await manifestClient.batchGetImage({
+      registryId: registryId ?? parsed.registryId,
+      repositoryName: parsed.repositoryName,
+      imageIds: imageDigests.map((imageDigest) => ({ imageDigest })),
+      acceptedMediaTypes: manifestMediaTypes,
+    });
+    const failure = response.failures?.[0];
+    if (failure !== undefined) {
+      throw new Error(
+        `Could not read the manifest ${repositoryUrl}@${failure.imageId?.imageDigest}: ` +
+          failure.failureReason,
+      );
+    }
+    const manifests = new Map(
+      (response.images ?? []).map((image) => [image.imageId?.imageDigest, image.imageManifest]),
+    );
+    return imageDigests.map((digest) => JSON.parse(manifests.get(digest) ?? "{}"));
+  };
+
+  const requested = (["sbom", "provenance"] as const).filter((kind) => attestations[kind] === true);
+  const indexes = await getManifests(digests);
+  const refs: string[] = [];
+  for (let i = 0; i < digests.length; i++) {
+    const [digest, index] = [digests[i], indexes[i]];
+    const attestationDigests = (index.manifests ?? [])
+      .filter((m) => m.annotations?.[referenceTypeAnnotation] === "attestation-manifest")
+      .map((m) => m.digest);
+    const predicateTypes =
+      attestationDigests.length === 0
+        ? []
+        : (await getManifests(attestationDigests)).flatMap((manifest) =>
+            (manifest.layers ?? []).map((layer) => layer.annotations?.[predicateTypeAnnotation]),
+          );
+    const missing = requested.filter(
+      (kind) => !predicateTypes.some((type) => type?.startsWith(predicateTypePrefixes[kind])),
+    );
+    if (missing.length > 0) {
+      throw new Error(
+        `The image ${repositoryUrl}@${digest} was pushed without the requested ` +
+          `${missing.join(" and ")} attestations. Check that the builder supports attestations.`,
+      );
+    }
+    refs.push(...attestationDigests.map((d) => `${repositoryUrl}@${d}`));
+  }
+  return refs;
+}
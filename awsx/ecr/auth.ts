@@ -30,6 +30,12 @@ export interface CredentialArgs {
    * If not provided, will be parsed from the registry URL.
    */
   registryId?: string;
+
+  /**
+   * Optional region of the ECR registry.
+   * If not provided, the region of the AWS provider is used.
+   */
+  region?: string;
 }
 
 /**
@@ -94,7 +100,10 @@ export function getDockerCredentials(
     registryId = hostnameParts[0];
   }
 
-  const ecrCredentials = aws.ecr.getAuthorizationTokenOutput({ registryId: registryId }, opts);
+  const ecrCredentials = aws.ecr.getAuthorizationTokenOutput(
+    { registryId: registryId, region: args.region },
+    opts,
+  );
 
   return ecrCredentials.apply((creds) => {
     if (!creds.userName || !creds.password) {
@@ -12,16 +12,26 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
+import * as fs from "fs";
+import * as os from "os";
+import * as path from "path";
 import * as pulumi from "@pulumi/pulumi";
 
+const registered: pulumi.runtime.MockResourceArgs[] = [];
 pulumi.runtime.setMocks(
   {
     newResource: function (args: pulumi.runtime.MockResourceArgs): { id: string; state: any } {
+      registered.push(args);
       return {
         id: `${args.name}_id`,
         state: {
           ...args.inputs,
-          digest: "sha256:mock-digest",
+          digest: args.inputs.platforms?.length
+            ? `sha256:mock-${args.inputs.platforms[0]}`
+            : "sha256:mock-digest",
+          ...(args.type === "docker-build:index:Index"
+            ? { ref: `${args.inputs.tag}@sha256:mock-index-digest` }
+            : {}),
         },
       };
     },
@@ -38,7 +48,8 @@ pulumi.runtime.setMocks(
   false,
 );
 
-import { Image, removeTagFromRef } from "./image";
+import * as attestations from "./attestations";
+import { computeImageFromAsset, Image, readBuildSecrets, removeTagFromRef } from "./image";
 
 describe("removeTagFromRef", () => {
   it("should remove tag from standard ECR image reference", () => {
@@ -93,6 +104,188 @@ describe("removeTagFromRef", () => {
   });
 });
 
+describe("Image", () => {
+  const repositoryUrl = "123456789012.dkr.ecr.us-west-2.amazonaws.com/app";
+
+  it("pushes a manifest list when building for multiple platforms", async () => {
+    const image = new Image("multi", {
+      repositoryUrl,
+      imageTag: "v1",
+      platforms: ["linux/amd64", "linux/arm64"],
+    });
+
+    await expect(promisify(pulumi.output(image.imageUri))).resolves.toBe(
+      `${repositoryUrl}@sha256:mock-index-digest`,
+    );
+    await expect(promisify(pulumi.output(image.digest))).resolves.toBe("sha256:mock-index-digest");
+    await expect(promisify(pulumi.output(image.platformDigests))).resolves.toEqual({
+      "linux/amd64": "sha256:mock-linux/amd64",
+      "linux/arm64": "sha256:mock-linux/arm64",
+    });
+  });
+
+  it("reports the digest of a single platform build", async () => {
+    const image = new Image("single", {
+      repositoryUrl,
+      imageTag: "v1",
+      platform: "linux/arm64",
+    });
+
+    await expect(promisify(pulumi.output(image.platformDigests))).resolves.toEqual({
+      "linux/arm64": "sha256:mock-linux/arm64",
+    });
+  });
+
+  it("exports a max mode registry cache to a sibling repository", async () => {
+    const image = new Image("cached", {
+      repositoryUrl,
+      imageTag: "cached",
+      cache: { repositoryUrl: `${repositoryUrl}-cache` },
+    });
+    await promisify(pulumi.output(image.imageUri));
+
+    const build = registered.find(
+      (r) => r.type === "docker-build:index:Image" && r.name === "cached",
+    )!;
+    expect(build.inputs.cacheFrom).toEqual([
+      { registry: { ref: `${repositoryUrl}-cache:buildcache` } },
+    ]);
+    expect(build.inputs.cacheTo).toEqual([
+      {
+        registry: {
+          ref: `${repositoryUrl}-cache:buildcache`,
+          mode: "max",
+          imageManifest: true,
+          ociMediaTypes: true,
+        },
+      },
+    ]);
+  });
+
+  it("pushes and returns every tag", async () => {
+    const image = new Image("tagged", {
+      repositoryUrl,
+      imageTag: "release",
+      tags: ["abc123", "latest", "release"],
+    });
+
+    await expect(promisify(pulumi.output(image.tags))).resolves.toEqual([
+      "release",
+      "abc123",
+      "latest",
+    ]);
+    await expect(promisify(pulumi.output(image.taggedUris))).resolves.toEqual([
+      `${repositoryUrl}:release`,
+      `${repositoryUrl}:abc123`,
+      `${repositoryUrl}:latest`,
+    ]);
+    const build = registered.find(
+      (r) => r.type === "docker-build:index:Image" && r.name === "release",
+    )!;
+    expect(build.inputs.tags).toEqual([
+      `${repositoryUrl}:release`,
+      `${repositoryUrl}:abc123`,
+      `${repositoryUrl}:latest`,
+    ]);
+  });
+
+  it("pushes by digest before pushing immutable tags", async () => {
+    const image = new Image("checked", {
+      repositoryUrl,
+      imageTag: "v1",
+      checkImmutableTags: true,
+    });
+
+    await expect(promisify(pulumi.output(image.tags))).resolves.toEqual(["v1"]);
+    const untagged = registered.find(
+      (r) => r.type === "docker-build:index:Image" && r.name === "v1-untagged",
+    )!;
+    expect(untagged.inputs.push).toBe(false);
+    expect(untagged.inputs.tags).toBeUndefined();
+    expect(untagged.inputs.exports).toEqual([
+      { registry: { names: [repositoryUrl], pushByDigest: true } },
+    ]);
+    const build = registered.find((r) => r.type === "docker-build:index:Image" && r.name === "v1")!;
+    expect(build.inputs.tags).toEqual([`${repositoryUrl}:v1`]);
+  });
+
+  it("has BuildKit attach attestations when pushing", async () => {
+    const resolve = jest
+      .spyOn(attestations, "attestationRefs")
+      .mockReturnValue(pulumi.output([`${repositoryUrl}@sha256:mock-attestation`]));
+    const image = new Image("attested", {
+      repositoryUrl,
+      imageTag: "attested",
+      attestations: { sbom: true, provenance: true },
+    });
+
+    await expect(promisify(pulumi.output(image.attestationRefs))).resolves.toEqual([
+      `${repositoryUrl}@sha256:mock-attestation`,
+    ]);
+    expect(resolve).toHaveBeenCalledWith(repositoryUrl, undefined, ["sha256:mock-digest"], {
+      sbom: true,
+      provenance: true,
+    });
+    resolve.mockRestore();
+    const build = registered.find(
+      (r) => r.type === "docker-build:index:Image" && r.name === "attested",
+    )!;
+    expect(build.inputs.push).toBeUndefined();
+    expect(build.inputs.exports).toEqual([
+      { registry: {}, attestations: { sbom: true, provenance: true } },
+    ]);
+  });
+
+  it("can't check immutable tags of multi-platform images", () => {
+    const parent = new pulumi.ComponentResource("test:index:Parent", "checked-platforms");
+    expect(() =>
+      computeImageFromAsset(
+        {
+          repositoryUrl,
+          imageTag: "v2",
+          platforms: ["linux/amd64", "linux/arm64"],
+          checkImmutableTags: true,
+        },
+        parent,
+      ),
+    ).toThrow("Only one of [platforms] or [checkImmutableTags] can be specified");
+  });
+
+  it("reads build secrets from the environment variables and files they reference", async () => {
+    const dir = fs.mkdtempSync(path.join(os.tmpdir(), "secrets-"));
+    const src = path.join(dir, "npmrc");
+    fs.writeFileSync(src, "file-token");
+    process.env.TEST_NPM_TOKEN = "env-token";
+    try {
+      const image = new Image("secret", {
+        repositoryUrl,
+        imageTag: "secret",
+        secrets: { npmToken: { env: "TEST_NPM_TOKEN" }, npmrc: { src } },
+        ssh: [{ id: "default" }],
+      });
+
+      await expect(pulumi.isSecret(pulumi.output(image.imageUri))).resolves.toBe(false);
+      const build = registered.find(
+        (r) => r.type === "docker-build:index:Image" && r.name === "secret",
+      )!;
+      expect(build.inputs.secrets).toEqual({ npmToken: "env-token", npmrc: "file-token" });
+      expect(build.inputs.ssh).toEqual([{ id: "default" }]);
+    } finally {
+      delete process.env.TEST_NPM_TOKEN;
+      fs.rmSync(dir, { recursive: true });
+    }
+  });
+
+  it("requires exactly one source per build secret", () => {
+    expect(() => readBuildSecrets({ npmToken: {} })).toThrow(
+      "Exactly one of [secrets.npmToken.env] or [secrets.npmToken.src] must be specified",
+    );
+    expect(() => readBuildSecrets({ npmToken: { env: "TEST_UNSET_NPM_TOKEN" } })).toThrow(
+      'Environment variable TEST_UNSET_NPM_TOKEN of build secret "npmToken" is not set',
+    );
+  });
+});
+
 function promisify<T>(output: pulumi.Output<T>): Promise<T> {
   return new Promise((resolve) => output.apply(resolve));
 }
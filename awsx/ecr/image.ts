@@ -12,12 +12,22 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
+import * as fs from "fs";
 import * as aws from "@pulumi/aws";
 import * as docker from "@pulumi/docker-build";
 import * as pulumi from "@pulumi/pulumi";
 import * as schema from "../schema-types";
 import * as utils from "../utils";
-import { getDockerCredentials } from "./auth";
+import { attestationRefs } from "./attestations";
+import { DockerCredentials, getDockerCredentials } from "./auth";
+import { checkImmutableTags } from "./immutableTags";
+import {
+  scanFindingsSummary,
+  ScanFindingsSummary,
+  scanGateHooks,
+  toScanFindingsSummaryOutputs,
+} from "./scanGate";
+import { signImage } from "./signing";
 
 export class Image extends schema.Image {
   constructor(name: string, args: schema.ImageArgs, opts: pulumi.ComponentResourceOptions = {}) {
@@ -26,17 +36,117 @@ export class Image extends schema.Image {
       return; // Rehydrating, skip construction
     }
 
-    this.imageUri = pulumi.output(args).apply((args) => computeImageFromAsset(args, this));
-    this.registerOutputs({ imageUri: this.imageUri });
+    const image = pulumi.output(args).apply((args) => computeImageFromAsset(args, this));
+    this.imageUri = image.imageUri;
+    this.digest = image.digest;
+    this.platformDigests = image.platformDigests;
+    this.tags = image.tags;
+    this.taggedUris = image.taggedUris;
+    this.scanFindings = image.scanFindings.apply((summary) =>
+      summary === undefined ? undefined : toScanFindingsSummaryOutputs(summary),
+    );
+    this.signatureRefs = image.signatureRefs;
+    this.attestationRefs = image.attestationRefs;
+    this.registerOutputs({
+      imageUri: this.imageUri,
+      digest: this.digest,
+      platformDigests: this.platformDigests,
+      tags: this.tags,
+      taggedUris: this.taggedUris,
+      scanFindings: this.scanFindings,
+      signatureRefs: this.signatureRefs,
+      attestationRefs: this.attestationRefs,
+    });
   }
 }
 
+/** @internal */
+export interface BuiltImage {
+  imageUri: string;
+  digest?: string;
+  platformDigests?: Record<string, string>;
+  tags: string[];
+  taggedUris: string[];
+  scanFindings?: ScanFindingsSummary;
+  signatureRefs?: string[];
+  attestationRefs?: string[];
+}
+
 /** @internal */
 export function computeImageFromAsset(
   args: pulumi.Unwrap<schema.ImageArgs>,
   parent: pulumi.Resource,
-) {
-  const { repositoryUrl, registryId: inputRegistryId, imageTag, ...dockerInputs } = args ?? {};
+): pulumi.Output<BuiltImage> {
+  const { scanGate, signing, attestations, ...buildArgs } = args;
+  let image = buildAndPushImage(buildArgs, parent, scanGate, attestations);
+  if (scanGate !== undefined) {
+    // A manifest list isn't scanned itself, only the images of each platform are.
+    const digests = image.apply((image) =>
+      image.platformDigests !== undefined
+        ? Object.values(image.platformDigests)
+        : image.digest !== undefined
+        ? [image.digest]
+        : [],
+    );
+    // The gate itself runs in hooks of the pushed images, this only reports the findings.
+    const scanFindings = scanFindingsSummary(
+      args.repositoryUrl,
+      args.registryId,
+      digests,
+      scanGate,
+    );
+    image = pulumi
+      .all([image, scanFindings])
+      .apply(([image, scanFindings]) => ({ ...image, scanFindings }));
+  }
+
+  if (attestations?.sbom || attestations?.provenance) {
+    // BuildKit stores the attestations in the index pushed for each platform image.
+    image = image.apply((image) =>
+      attestationRefs(
+        args.repositoryUrl,
+        args.registryId,
+        Object.values(image.platformDigests ?? { default: image.digest! }),
+        attestations,
+      ).apply((refs) => ({ ...image, attestationRefs: refs })),
+    );
+  }
+
+  if (signing !== undefined) {
+    // The pushed images only resolve once their scan gate hooks passed, so only images that
+    // passed it are signed.
+    image = image.apply((image) =>
+      signImage(args.repositoryUrl, args.registryId, image.digest, signing, parent).apply(
+        (signatureRefs) => ({ ...image, signatureRefs }),
+      ),
+    );
+  }
+  return image;
+}
+
+function buildAndPushImage(
+  args: pulumi.Unwrap<Omit<schema.ImageArgs, "scanGate" | "signing" | "attestations">>,
+  parent: pulumi.Resource,
+  scanGate?: pulumi.Unwrap<schema.ScanGateInputs>,
+  attestations?: pulumi.Unwrap<schema.ImageAttestationsInputs>,
+): pulumi.Output<BuiltImage> {
+  const {
+    repositoryUrl,
+    registryId: inputRegistryId,
+    imageTag,
+    tags: extraTags,
+    checkImmutableTags: checkTags,
+    secrets,
+    /* tslint:disable */ //rest args will always be last so don't have trailing commas
+    ...dockerInputs
+    /* tslint:enable */
+  } = args ?? {};
+  if (dockerInputs.platform !== undefined && dockerInputs.platforms !== undefined) {
+    throw new Error("Only one of [platform] or [platforms] can be specified");
+  }
+  if (dockerInputs.platforms !== undefined && checkTags) {
+    throw new Error("Only one of [platforms] or [checkImmutableTags] can be specified");
+  }
 
   pulumi.log.debug(`Building container image at '${JSON.stringify(dockerInputs)}'`, parent);
 
@@ -44,10 +154,19 @@ export function computeImageFromAsset(
     ? args.imageName
     : imageTag
     ? imageTag
+    : extraTags !== undefined && extraTags.length > 0
+    ? extraTags[0]
     : createUniqueImageName(dockerInputs);
 
   // Note: the tag, if provided, is included in the image name.
   const canonicalImageName = `${repositoryUrl}:${imageName}`;
+  const tags = [...new Set([imageName, ...(extraTags ?? [])])];
+  const taggedUris = tags.map((tag) => `${repositoryUrl}:${tag}`);
+  // The scan gate runs whenever a pushed image gets a new digest.
+  const hooks =
+    scanGate === undefined
+      ? undefined
+      : scanGateHooks(canonicalImageName, repositoryUrl, inputRegistryId, scanGate);
 
   // If we haven't, build and push the local build context to the ECR repository.  Then return
   // the unique image name we pushed to.  The name will change if the image changes ensuring
@@ -58,40 +177,73 @@ export function computeImageFromAsset(
     { parent },
   );
 
-  let cacheFrom: docker.types.input.CacheFromArgs[] = [];
-  if (dockerInputs.cacheFrom !== undefined) {
-    cacheFrom = dockerInputs.cacheFrom.map((c) => {
-      return {
-        registry: {
-          ref: c,
-        },
-      };
-    });
-  }
-  // Use an inline cache by default.
-  if (cacheFrom.length === 0) {
-    cacheFrom.push({ registry: { ref: canonicalImageName } });
-  }
+  const cacheArgsFor = (imageRef: string, suffix?: string) =>
+    cacheArgs(dockerInputs.cacheFrom, dockerInputs.cache, repositoryUrl, imageRef, suffix);
 
   let context = ".";
   if (dockerInputs.context !== undefined) {
     context = dockerInputs.context;
   }
 
+  const attest = attestations?.sbom === true || attestations?.provenance === true;
   const dockerImageArgs: docker.ImageArgs = {
-    tags: [canonicalImageName],
     buildArgs: dockerInputs.args,
-    cacheFrom: cacheFrom,
-    cacheTo: [{ inline: {} }],
+    ...cacheArgsFor(canonicalImageName),
     context: { location: context },
     dockerfile: { location: dockerInputs.dockerfile },
     platforms: dockerInputs.platform ? [dockerInputs.platform as docker.Platform] : [],
     target: dockerInputs.target,
-    push: true,
+    secrets: readBuildSecrets(secrets),
+    ssh: dockerInputs.ssh,
+    // A registry export pushes to the tags of the image just like `push` does.
+    ...(attest ? { exports: [registryExport({}, attestations)] } : { push: true }),
     registries: [registryCredentials],
   };
 
-  const image = new docker.Image(imageName, dockerImageArgs, { parent });
+  if (dockerInputs.platforms !== undefined && dockerInputs.platforms.length > 0) {
+    return buildMultiPlatformImage(
+      imageName,
+      canonicalImageName,
+      repositoryUrl,
+      dockerInputs.platforms,
+      dockerImageArgs,
+      cacheArgsFor,
+      tags,
+      registryCredentials,
+      parent,
+      hooks,
+    );
+  }
+
+  let tagsAvailable = pulumi.output<void>(undefined);
+  if (checkTags) {
+    // Push the image by digest without tags first, so that its digest can be compared with the
+    // existing tags before any of them is pushed.
+    const untagged = new docker.Image(
+      `${imageName}-untagged`,
+      {
+        ...dockerImageArgs,
+        push: false,
+        exports: [registryExport({ names: [repositoryUrl], pushByDigest: true }, attestations)],
+      },
+      // Gating the untagged image keeps the tags from being pushed before the gate passes.
+      { parent, hooks },
+    );
+    tagsAvailable = checkImmutableTags(
+      repositoryUrl,
+      inputRegistryId,
+      tags,
+      untagged.digest,
+      parent,
+    );
+  }
+
+  // With [checkImmutableTags] this is a rebuild of the untagged image from the build cache.
+  const image = new docker.Image(
+    imageName,
+    { ...dockerImageArgs, tags: tagsAvailable.apply(() => taggedUris) },
+    { parent, hooks: checkTags ? undefined : hooks },
+  );
 
   image.ref.apply((ref) => {
     pulumi.log.debug(`    build complete: ${ref}`, parent);
@@ -99,7 +251,7 @@ export function computeImageFromAsset(
 
   // Return the image reference without the tag. This is necessary for backwards compatibility with earlier versions of the awsx provider
   // that used pulumi-docker and in order to allow passing this output to Lambda functions (they expect an image URI without a tag).
-  return pulumi.all([image.ref, image.digest]).apply(([ref, digest]) => {
+  const imageUri = pulumi.all([image.ref, image.digest]).apply(([ref, digest]) => {
 
     if (digest) {
       return `${repositoryUrl}@${digest}`;
@@ -113,6 +265,182 @@ export function computeImageFromAsset(
     // restores the digest-form image URI via the branches above.
     return canonicalImageName;
   });
+  const platform = dockerInputs.platform;
+  return pulumi.output({
+    imageUri,
+    tags,
+    taggedUris,
+    digest: image.digest,
+    platformDigests: image.digest.apply((digest) =>
+      platform && digest ? { [platform]: digest } : undefined,
+    ),
+  });
+}
+
+/**
+ * Builds one image per platform, each pushed under its own tag, and then pushes a manifest list
+ * referencing all of them under each of the tagged URIs. Building the platforms separately is
+ * what lets us report the digest of each platform image alongside the manifest-list digest.
+ */
+function buildMultiPlatformImage(
+  imageName: string,
+  canonicalImageName: string,
+  repositoryUrl: string,
+  platforms: string[],
+  dockerImageArgs: docker.ImageArgs,
+  cacheArgsFor: (imageRef: string, suffix: string) => CacheArgs,
+  tags: string[],
+  registryCredentials: pulumi.Output<DockerCredentials>,
+  parent: pulumi.Resource,
+  hooks?: pulumi.ResourceHookBinding,
+): pulumi.Output<BuiltImage> {
+  const images = platforms.map((platform) => {
+    const suffix = platformSuffix(platform);
+    const platformTag = `${canonicalImageName}-${suffix}`;
+    return new docker.Image(
+      `${imageName}-${suffix}`,
+      {
+        ...dockerImageArgs,
+        tags: [platformTag],
+        // Each platform keeps its own cache so that the builds don't evict each other's layers.
+        ...cacheArgsFor(platformTag, suffix),
+        platforms: [platform as docker.Platform],
+      },
+      { parent, hooks },
+    );
+  });
+
+  // An index can only be pushed with a single tag. All of them reference the same sources, so
+  // they share one digest.
+  const indexes = tags.map(
+    (tag, i) =>
+      new docker.Index(
+        i === 0 ? imageName : `${imageName}-${tag}`,
+        {
+          tag: `${repositoryUrl}:${tag}`,
+          sources: images.map((image) => image.ref),
+          push: true,
+          registry: registryCredentials,
+        },
+        { parent },
+      ),
+  );
+  const index = indexes[0];
+
+  const digest = index.ref.apply((ref) => digestFromRef(ref));
+  return pulumi.output({
+    tags,
+    taggedUris: tags.map((tag) => `${repositoryUrl}:${tag}`),
+    imageUri: pulumi
+      .all([index.ref, digest])
+      .apply(([ref, digest]) => (digest ? `${repositoryUrl}@${digest}` : removeTagFromRef(ref))),
+    digest,
+    platformDigests: pulumi
+      .all(images.map((image) => image.digest))
+      .apply((digests) => Object.fromEntries(platforms.map((p, i) => [p, digests[i]]))),
+  });
+}
+
+/**
+ * Returns a registry export that has BuildKit attach the requested SBOM and provenance
+ * attestations to the pushed image.
+ */
+function registryExport(
+  registry: docker.types.input.ExportRegistryArgs,
+  attestations?: pulumi.Unwrap<schema.ImageAttestationsInputs>,
+): docker.types.input.ExportArgs {
+  if (!attestations?.sbom && !attestations?.provenance) {
+    return { registry };
+  }
+  return {
+    registry,
+    attestations: { sbom: attestations.sbom, provenance: attestations.provenance },
+  };
+}
+
+/**
+ * Reads the values of the build secrets from the environment variables or files they reference.
+ * The values are only read when the image is built and never become inputs of this component.
+ * @internal
+ */
+export function readBuildSecrets(
+  secrets: Record<string, schema.BuildSecretInputs> | undefined,
+): pulumi.Output<Record<string, string>> | undefined {
+  if (secrets === undefined) {
+    return undefined;
+  }
+  const values: Record<string, string> = {};
+  for (const [id, { env, src }] of Object.entries(secrets)) {
+    if (utils.countDefined([env, src]) !== 1) {
+      throw new Error(
+        `Exactly one of [secrets.${id}.env] or [secrets.${id}.src] must be specified`,
+      );
+    }
+    if (env !== undefined) {
+      const value = process.env[env];
+      if (value === undefined) {
+        throw new Error(`Environment variable ${env} of build secret "${id}" is not set`);
+      }
+      values[id] = value;
+    } else {
+      values[id] = fs.readFileSync(src!, "utf8");
+    }
+  }
+  return pulumi.secret(values);
+}
+
+type CacheArgs = Pick<docker.ImageArgs, "cacheFrom" | "cacheTo">;
+
+/**
+ * Imports from the given cache sources and the registry cache, and exports to the registry
+ * cache. Without a registry cache, the image is built with an inline cache that is also imported
+ * from the image itself unless other cache sources were given.
+ */
+function cacheArgs(
+  cacheFrom: string[] | undefined,
+  cache: pulumi.Unwrap<schema.BuildCacheInputs> | undefined,
+  repositoryUrl: string,
+  imageRef: string,
+  suffix?: string,
+): CacheArgs {
+  const sources: docker.types.input.CacheFromArgs[] = (cacheFrom ?? []).map((ref) => ({
+    registry: { ref },
+  }));
+  if (cache === undefined) {
+    if (sources.length === 0) {
+      sources.push({ registry: { ref: imageRef } });
+    }
+    return { cacheFrom: sources, cacheTo: [{ inline: {} }] };
+  }
+
+  const tag = cache.tag ?? "buildcache";
+  const ref = `${cache.repositoryUrl ?? repositoryUrl}:${suffix ? `${tag}-${suffix}` : tag}`;
+  sources.push({ registry: { ref } });
+  return {
+    cacheFrom: sources,
+    cacheTo: [
+      {
+        registry: {
+          ref,
+          mode: (cache.mode ?? "max") as docker.CacheMode,
+          // ECR only accepts cache manifests in the OCI image format.
+          imageManifest: true,
+          ociMediaTypes: true,
+        },
+      },
+    ],
+  };
+}
+
+/** Turns a platform like `linux/arm64/v8` into a string usable in tags and resource names. */
+function platformSuffix(platform: string): string {
+  return platform.replace(/\//g, "-");
+}
+
+/** @internal */
+export function digestFromRef(ref: string): string | undefined {
+  const at = ref.lastIndexOf("@");
+  return at === -1 ? undefined : ref.slice(at + 1);
 }
 
 function createUniqueImageName(inputs: pulumi.Unwrap<schema.DockerBuildInputs>): string {
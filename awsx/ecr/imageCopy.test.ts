@@ -0,0 +1,118 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import * as pulumi from "@pulumi/pulumi";
+
+import { mockResources } from "../tests/mocks";
+import { ImageCopy, resolveSourceImage } from "./imageCopy";
+
+function promiseOf<T>(output: pulumi.Input<T> | undefined): Promise<T> {
+  return new Promise((resolve) => pulumi.output(output!).apply(resolve));
+}
+
+const sourceUrl = "111111111111.dkr.ecr.us-west-2.amazonaws.com/app";
+const destinationUrl = "222222222222.dkr.ecr.eu-west-1.amazonaws.com/app";
+const sourceDigest = `sha256:${"a".repeat(64)}`;
+const taggedDigest = `sha256:${"b".repeat(64)}`;
+
+describe("ImageCopy", () => {
+  const calls: pulumi.runtime.MockCallArgs[] = [];
+  const { registeredResource } = mockResources({
+    state: (args) =>
+      args.type === "docker-build:index:Index"
+        ? { ref: `${args.inputs.tag}@${args.inputs.sources[0].split("@")[1]}` }
+        : undefined,
+    call: (args) => {
+      calls.push(args);
+      switch (args.token) {
+        case "aws:ecr/getAuthorizationToken:getAuthorizationToken":
+          return {
+            userName: "AWS",
+            password: `token-${args.inputs.registryId}`,
+            proxyEndpoint: `https://${args.inputs.registryId}.dkr.ecr.${args.inputs.region}.amazonaws.com`,
+          };
+        case "aws:ecr/getImage:getImage":
+          return { imageDigest: taggedDigest };
+        default:
+          return undefined;
+      }
+    },
+  });
+
+  it("copies the source image by digest with credentials for both registries", async () => {
+    const image = new ImageCopy("promoted", {
+      repositoryUrl: destinationUrl,
+      tag: "v1",
+      source: { repositoryUrl: sourceUrl, digest: sourceDigest },
+    });
+
+    const index = await registeredResource("docker-build:index:Index", "promoted");
+    expect(index.inputs.tag).toBe(`${destinationUrl}:v1`);
+    expect(index.inputs.sources).toEqual([`${sourceUrl}@${sourceDigest}`]);
+    expect(index.inputs.push).toBe(true);
+
+    const provider = await registeredResource("pulumi:providers:docker-build", "promoted");
+    // Object-typed provider config is passed to the provider as JSON.
+    const registries =
+      typeof provider.inputs.registries === "string"
+        ? JSON.parse(provider.inputs.registries)
+        : provider.inputs.registries;
+    expect(registries.map((r: { address: string }) => r.address)).toEqual([
+      "https://111111111111.dkr.ecr.us-west-2.amazonaws.com",
+      "https://222222222222.dkr.ecr.eu-west-1.amazonaws.com",
+    ]);
+
+    expect(await promiseOf(image.digest)).toBe(sourceDigest);
+    expect(await promiseOf(image.imageUri)).toBe(`${destinationUrl}@${sourceDigest}`);
+  });
+
+  it("resolves a source tag to a digest", async () => {
+    const image = new ImageCopy("promoted-tag", {
+      repositoryUrl: destinationUrl,
+      source: { repositoryUrl: sourceUrl, tag: "release" },
+    });
+
+    const index = await registeredResource("docker-build:index:Index", "promoted-tag");
+    expect(index.inputs.tag).toBe(`${destinationUrl}:latest`);
+    expect(index.inputs.sources).toEqual([`${sourceUrl}@${taggedDigest}`]);
+    expect(await promiseOf(image.digest)).toBe(taggedDigest);
+
+    const lookup = calls.find((c) => c.token === "aws:ecr/getImage:getImage");
+    expect(lookup?.inputs).toMatchObject({
+      repositoryName: "app",
+      registryId: "111111111111",
+      region: "us-west-2",
+      imageTag: "release",
+    });
+  });
+});
+
+describe("resolveSourceImage", () => {
+  const parent = new pulumi.ComponentResource("test:index:Parent", "parent");
+
+  it("requires exactly one of digest or tag", () => {
+    expect(() => resolveSourceImage({ repositoryUrl: sourceUrl }, parent)).toThrow(
+      "Exactly one of [source.digest] or [source.tag] must be specified",
+    );
+    expect(() =>
+      resolveSourceImage({ repositoryUrl: sourceUrl, digest: sourceDigest, tag: "v1" }, parent),
+    ).toThrow("Exactly one of [source.digest] or [source.tag] must be specified");
+  });
+
+  it("rejects digests that aren't sha256 digests", () => {
+    expect(() => resolveSourceImage({ repositoryUrl: sourceUrl, digest: "v1" }, parent)).toThrow(
+      '[source.digest] must be a sha256 digest, got "v1"',
+    );
+  });
+});
@@ -0,0 +1,99 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import * as aws from "@pulumi/aws";
+import * as docker from "@pulumi/docker-build";
+import * as pulumi from "@pulumi/pulumi";
+import * as schema from "../schema-types";
+import { getDockerCredentials } from "./auth";
+import { digestFromRef } from "./image";
+import { parseRepositoryUrl } from "./immutableTags";
+
+/**
+ * Copies the source image by digest with `docker buildx imagetools create`, which copies the
+ * layers between the registries without pulling the image locally. An index with a single source
+ * is pushed as is, so the image keeps its digest.
+ */
+export class ImageCopy extends schema.ImageCopy {
+  constructor(name: string, args: schema.ImageCopyArgs, opts?: pulumi.ComponentResourceOptions) {
+    super(name, args, opts);
+
+    const tagName = args.tag ? args.tag : "latest";
+    const source = pulumi.output(args.source);
+    const sourceRef = source.apply((source) => resolveSourceImage(source, this));
+
+    // The source may live in another account or region, so each registry needs its own token.
+    const credentialsFor = (url: string) => {
+      const { registryId, region } = parseRepositoryUrl(url);
+      return getDockerCredentials({ repositoryUrl: url, registryId, region }, { parent: this });
+    };
+    const provider = new docker.Provider(
+      name,
+      {
+        registries: [
+          source.apply((source) => credentialsFor(source.repositoryUrl)),
+          pulumi.output(args.repositoryUrl).apply(credentialsFor),
+        ],
+      },
+      { parent: this },
+    );
+
+    const index = new docker.Index(
+      name,
+      {
+        tag: pulumi.interpolate`${args.repositoryUrl}:${tagName}`,
+        sources: [sourceRef],
+        push: true,
+      },
+      { parent: this, provider },
+    );
+    this.digest = pulumi.all([index.ref, sourceRef]).apply(([ref, sourceRef]) => {
+      const digest = digestFromRef(ref) ?? digestFromRef(sourceRef);
+      if (digest === undefined) {
+        throw new Error(`Could not determine the digest of the copied image ${ref}`);
+      }
+      return digest;
+    });
+    this.imageUri = pulumi.interpolate`${args.repositoryUrl}@${this.digest}`;
+    this.registerOutputs({
+      digest: this.digest,
+      imageUri: this.imageUri,
+    });
+  }
+}
+
+/**
+ * Returns a reference to the source image pinned by digest, resolving the tag if needed.
+ * @internal
+ */
+export function resolveSourceImage(
+  source: pulumi.Unwrap<schema.ImageCopySourceInputs>,
+  parent: pulumi.Resource,
+): pulumi.Output<string> {
+  const { repositoryUrl, digest, tag } = source;
+  if ((digest === undefined) === (tag === undefined)) {
+    throw new Error("Exactly one of [source.digest] or [source.tag] must be specified");
+  }
+  if (digest !== undefined) {
+    if (!/^sha256:[0-9a-f]{64}$/.test(digest)) {
+      throw new Error(`[source.digest] must be a sha256 digest, got "${digest}"`);
+    }
+    return pulumi.output(`${repositoryUrl}@${digest}`);
+  }
+
+  const { registryId, repositoryName, region } = parseRepositoryUrl(repositoryUrl);
+  return aws.ecr
+    .getImageOutput({ repositoryName, registryId, region, imageTag: tag }, { parent })
+    .imageDigest.apply((digest) => `${repositoryUrl}@${digest}`);
+}
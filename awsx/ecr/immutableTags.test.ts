@@ -0,0 +1,51 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import { checkTagsAvailable } from "./immutableTags";
+
+describe("checkTagsAvailable", () => {
+  const imageIds = [
+    { imageTag: "v1", imageDigest: "sha256:one" },
+    { imageTag: "latest", imageDigest: "sha256:one" },
+    { imageTag: "v0", imageDigest: "sha256:zero" },
+    { imageDigest: "sha256:untagged" },
+  ];
+
+  it("allows tags that don't exist yet", () => {
+    expect(() => checkTagsAvailable("app", ["v2", "abc123"], "sha256:two", imageIds)).not.toThrow();
+  });
+
+  it("allows tags that already point to the image", () => {
+    expect(() => checkTagsAvailable("app", ["v1", "latest"], "sha256:one", imageIds)).not.toThrow();
+  });
+
+  it("allows adding a new tag to an unchanged image", () => {
+    expect(() => checkTagsAvailable("app", ["v1", "v1.0.1"], "sha256:one", imageIds)).not.toThrow();
+  });
+
+  it("rejects moving an existing tag to a changed image", () => {
+    expect(() => checkTagsAvailable("app", ["v2", "latest"], "sha256:two", imageIds)).toThrow(
+      "Tags [latest] (sha256:one) already point to another image in the immutable repository [app]",
+    );
+  });
+
+  it("names every conflicting tag", () => {
+    expect(() => checkTagsAvailable("app", ["v0", "v1"], "sha256:one", imageIds)).toThrow(
+      "Tags [v0] (sha256:zero) already point",
+    );
+    expect(() => checkTagsAvailable("app", ["v0", "v1"], "sha256:two", imageIds)).toThrow(
+      "Tags [v0] (sha256:zero), [v1] (sha256:one)",
+    );
+  });
+});
@@ -0,0 +1,96 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import * as aws from "@pulumi/aws";
+import * as pulumi from "@pulumi/pulumi";
+
+/**
+ * Resolves once it is safe to push the given tags for the image with the given digest. If the
+ * repository has immutable tags, pushing fails when any of the tags already points to another
+ * image, so this fails earlier with an error naming the tags instead.
+ * @internal
+ */
+export function checkImmutableTags(
+  repositoryUrl: string,
+  registryId: string | undefined,
+  tags: string[],
+  digest: pulumi.Output<string>,
+  parent: pulumi.Resource,
+): pulumi.Output<void> {
+  const { repositoryName, region } = parseRepositoryUrl(repositoryUrl);
+  const repository = aws.ecr.getRepositoryOutput(
+    { name: repositoryName, registryId, region },
+    { parent },
+  );
+  return repository.imageTagMutability.apply((mutability) => {
+    // Repositories with exclusion filters allow some tags to move, so leave those to ECR.
+    if (mutability !== "IMMUTABLE") {
+      return pulumi.output(undefined);
+    }
+    const imageIds = aws.ecr.getImagesOutput(
+      { repositoryName, registryId, region },
+      { parent },
+    ).imageIds;
+    return pulumi
+      .all([imageIds, digest])
+      .apply(([imageIds, digest]) => checkTagsAvailable(repositoryName, tags, digest, imageIds));
+  });
+}
+
+/**
+ * Splits a repository URL of the form ACCOUNT_ID.dkr.ecr.REGION.amazonaws.com/NAME into its parts.
+ * @internal
+ */
+export function parseRepositoryUrl(repositoryUrl: string): {
+  registryId: string;
+  repositoryName: string;
+  region?: string;
+} {
+  const [host, ...path] = repositoryUrl.split("/");
+  const hostParts = host.split(".");
+  return {
+    registryId: hostParts[0],
+    repositoryName: path.join("/"),
+    region: hostParts[1] === "dkr" && hostParts[2] === "ecr" ? hostParts[3] : undefined,
+  };
+}
+
+/**
+ * Throws if pushing the image with the given digest would move a tag that already points to
+ * another image. Tags already pointing to the image were pushed by an earlier deployment.
+ * @internal
+ */
+export function checkTagsAvailable(
+  repositoryName: string,
+  tags: string[],
+  digest: string,
+  imageIds: { imageTag?: string; imageDigest: string }[],
+) {
+  const digests = new Map<string, string>();
+  for (const { imageTag, imageDigest } of imageIds) {
+    if (imageTag !== undefined) {
+      digests.set(imageTag, imageDigest);
+    }
+  }
+
+  const conflicts = tags.filter((tag) => digests.has(tag) && digests.get(tag) !== digest);
+  if (conflicts.length === 0) {
+    return;
+  }
+  const described = conflicts.map((tag) => `[${tag}] (${digests.get(tag)})`).join(", ");
+  throw new Error(
+    `Tags ${described} already point to another image in the immutable repository [${repositoryName}]. ` +
+      "Use tags that don't exist yet or allow tags to be overwritten with [imageTagMutability].",
+  );
+}
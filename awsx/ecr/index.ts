@@ -14,4 +14,6 @@
 
 export * from "./repository";
 export * from "./image";
+export * from "./imageCopy";
+export * from "./registry";
 export * from "./registryImage";
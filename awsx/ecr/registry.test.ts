@@ -0,0 +1,144 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+import * as pulumi from "@pulumi/pulumi";
+
+import { mockResources } from "../tests/mocks";
+import { Registry, replicaRegistryUrls } from "./registry";
+import { Repository } from "./repository";
+
+function promiseOf<T>(output: pulumi.Input<T> | undefined): Promise<T> {
+  return new Promise((resolve) => pulumi.output(output!).apply(resolve));
+}
+
+describe("Registry", () => {
+  const { registeredResource } = mockResources({
+    call: (args) =>
+      args.token === "aws:index/getCallerIdentity:getCallerIdentity"
+        ? { accountId: "111111111111" }
+        : undefined,
+  });
+
+  it("replicates matching repositories and reports their replica URLs", async () => {
+    const registry = new Registry("replicated", {
+      replication: {
+        destinations: [
+          { region: "eu-west-1" },
+          { region: "us-east-1", registryId: "222222222222" },
+        ],
+        repositoryPrefixes: ["team/"],
+      },
+    });
+
+    const configuration = await registeredResource(
+      "aws:ecr/replicationConfiguration:ReplicationConfiguration",
+      "replicated",
+    );
+    expect(configuration.inputs.replicationConfiguration.rules).toEqual([
+      {
+        destinations: [
+          { region: "eu-west-1", registryId: "111111111111" },
+          { region: "us-east-1", registryId: "222222222222" },
+        ],
+        repositoryFilters: [{ filter: "team/", filterType: "PREFIX_MATCH" }],
+      },
+    ]);
+    await expect(promiseOf(registry.replicaRegistryUrls)).resolves.toEqual({
+      "eu-west-1": "111111111111.dkr.ecr.eu-west-1.amazonaws.com",
+      "222222222222/us-east-1": "222222222222.dkr.ecr.us-east-1.amazonaws.com",
+    });
+
+    const replicated = new Repository("api", { name: "team/api", registry }, {});
+    await expect(promiseOf(replicated.replicaUrls)).resolves.toEqual({
+      "eu-west-1": "111111111111.dkr.ecr.eu-west-1.amazonaws.com/team/api",
+      "222222222222/us-east-1": "222222222222.dkr.ecr.us-east-1.amazonaws.com/team/api",
+    });
+    const local = new Repository("tools", { name: "tools", registry }, {});
+    await expect(promiseOf(local.replicaUrls)).resolves.toEqual({});
+  });
+
+  it("uses the DNS suffix of the partition in the replica URLs", () => {
+    expect(
+      replicaRegistryUrls("111111111111", "cn-north-1", "amazonaws.com.cn", [
+        { region: "cn-northwest-1" },
+      ]),
+    ).toEqual({ "cn-northwest-1": "111111111111.dkr.ecr.cn-northwest-1.amazonaws.com.cn" });
+  });
+
+  it("rejects replicating to the source registry", () => {
+    expect(() =>
+      replicaRegistryUrls("111111111111", "us-west-2", "amazonaws.com", [
+        { region: "us-west-2", registryId: "111111111111" },
+      ]),
+    ).toThrow('Images can\'t be replicated to the source registry "us-west-2"');
+    expect(
+      replicaRegistryUrls("111111111111", "us-west-2", "amazonaws.com", [
+        { region: "us-west-2", registryId: "222222222222" },
+      ]),
+    ).toEqual({ "222222222222/us-west-2": "222222222222.dkr.ecr.us-west-2.amazonaws.com" });
+  });
+
+  it("stores upstream credentials for pull-through cache rules", async () => {
+    new Registry("cache", {
+      pullThroughCacheRules: {
+        "docker-hub": { upstream: "docker-hub", username: "me", accessToken: "token" },
+        quay: { upstream: "quay" },
+      },
+    });
+
+    const secret = await registeredResource("aws:secretsmanager/secret:Secret", "cache-docker-hub");
+    expect(secret.inputs.namePrefix).toBe("ecr-pullthroughcache/docker-hub-");
+    const dockerHub = await registeredResource(
+      "aws:ecr/pullThroughCacheRule:PullThroughCacheRule",
+      "cache-docker-hub",
+    );
+    expect(dockerHub.inputs).toMatchObject({
+      ecrRepositoryPrefix: "docker-hub",
+      upstreamRegistryUrl: "registry-1.docker.io",
+      credentialArn: "arn:cache-docker-hub",
+    });
+    const quay = await registeredResource(
+      "aws:ecr/pullThroughCacheRule:PullThroughCacheRule",
+      "cache-quay",
+    );
+    expect(quay.inputs.upstreamRegistryUrl).toBe("quay.io");
+    expect(quay.inputs.credentialArn).toBeUndefined();
+  });
+
+  it("requires credentials for GitHub Container Registry", () => {
+    expect(
+      () =>
+        new Registry("ghcr", {
+          pullThroughCacheRules: { ghcr: { upstream: "github-container-registry" } },
+        }),
+    ).toThrow('Pull-through cache rule "ghcr" requires [credentialArn] or [username]');
+  });
+
+  it("scans all repositories on push by default", async () => {
+    new Registry("scanned", { scanning: {} });
+
+    const configuration = await registeredResource(
+      "aws:ecr/registryScanningConfiguration:RegistryScanningConfiguration",
+      "scanned",
+    );
+    expect(configuration.inputs).toEqual({
+      scanType: "ENHANCED",
+      rules: [
+        {
+          scanFrequency: "SCAN_ON_PUSH",
+          repositoryFilters: [{ filter: "*", filterType: "WILDCARD" }],
+        },
+      ],
+    });
+  });
+});
@@ -0,0 +1,231 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import * as aws from "@pulumi/aws";
+import * as pulumi from "@pulumi/pulumi";
+import * as schema from "../schema-types";
+import * as utils from "../utils";
+
+const upstreamRegistryUrls: Record<schema.PullThroughCacheUpstreamInputs, string> = {
+  "docker-hub": "registry-1.docker.io",
+  "github-container-registry": "ghcr.io",
+  quay: "quay.io",
+};
+
+// Upstream registries that don't allow anonymous pulls through a cache.
+const upstreamsRequiringCredentials: schema.PullThroughCacheUpstreamInputs[] = [
+  "docker-hub",
+  "github-container-registry",
+];
+
+export class Registry extends schema.Registry {
+  constructor(name: string, args: schema.RegistryArgs, opts: pulumi.ComponentResourceOptions = {}) {
+    super(name, {}, opts);
+    if (opts.urn) {
+      return; // Rehydrating, skip construction
+    }
+
+    const { replication, pullThroughCacheRules, scanning, region } = args;
+    const regionArgs = region !== undefined ? { region } : {};
+
+    const registryId = aws.getCallerIdentityOutput({}, { parent: this }).accountId;
+    this.registryId = registryId;
+
+    const destinations = replication?.destinations ?? [];
+    if (replication !== undefined && destinations.length === 0) {
+      throw new Error("[replication.destinations] must contain at least one destination");
+    }
+    const prefixes = replication?.repositoryPrefixes ?? [];
+    const sourceRegion = region !== undefined ? pulumi.output(region) : utils.getRegion(this);
+    const dnsSuffix = aws.getPartitionOutput({}, { parent: this }).dnsSuffix;
+    this.replicaRegistryUrls = pulumi
+      .all([registryId, sourceRegion, dnsSuffix])
+      .apply(([accountId, sourceRegion, dnsSuffix]) =>
+        replicaRegistryUrls(accountId, sourceRegion, dnsSuffix, destinations),
+      );
+    if (replication !== undefined) {
+      this.replicationConfiguration = new aws.ecr.ReplicationConfiguration(
+        name,
+        {
+          ...regionArgs,
+          replicationConfiguration: {
+            rules: [
+              {
+                // The destinations are only configured once the replica URLs validated them.
+                destinations: pulumi
+                  .all([registryId, this.replicaRegistryUrls])
+                  .apply(([accountId]) =>
+                    destinations.map((d) => ({
+                      region: d.region,
+                      registryId: d.registryId ?? accountId,
+                    })),
+                  ),
+                repositoryFilters:
+                  prefixes.length > 0
+                    ? prefixes.map((filter) => ({ filter, filterType: "PREFIX_MATCH" }))
+                    : undefined,
+              },
+            ],
+          },
+        },
+        { parent: this },
+      );
+    }
+    this.replicatedRepositoryPrefixes = prefixes;
+
+    if (pullThroughCacheRules !== undefined) {
+      this.pullThroughCacheRules = Object.fromEntries(
+        Object.entries(pullThroughCacheRules).map(([prefix, rule]) => [
+          prefix,
+          this.createPullThroughCacheRule(name, prefix, rule, regionArgs),
+        ]),
+      );
+    }
+
+    if (scanning !== undefined) {
+      this.scanningConfiguration = new aws.ecr.RegistryScanningConfiguration(
+        name,
+        {
+          ...regionArgs,
+          scanType: scanning.scanType ?? "ENHANCED",
+          rules: pulumi.output(scanning.rules).apply((rules) =>
+            (rules ?? [{ frequency: "SCAN_ON_PUSH" }]).map((rule) => ({
+              scanFrequency: rule.frequency,
+              repositoryFilters: (rule.repositoryFilters ?? ["*"]).map((filter) => ({
+                filter,
+                filterType: "WILDCARD",
+              })),
+            })),
+          ),
+        },
+        { parent: this },
+      );
+    }
+
+    this.registerOutputs({
+      registryId: this.registryId,
+      replicationConfiguration: this.replicationConfiguration,
+      replicaRegistryUrls: this.replicaRegistryUrls,
+      replicatedRepositoryPrefixes: this.replicatedRepositoryPrefixes,
+      pullThroughCacheRules: this.pullThroughCacheRules,
+      scanningConfiguration: this.scanningConfiguration,
+    });
+  }
+
+  private createPullThroughCacheRule(
+    name: string,
+    prefix: string,
+    rule: schema.PullThroughCacheRuleInputs,
+    regionArgs: { region?: pulumi.Input<string> },
+  ): aws.ecr.PullThroughCacheRule {
+    if (rule.credentialArn !== undefined && rule.username !== undefined) {
+      throw new Error(
+        `Only one of [credentialArn] or [username] can be specified for pull-through cache rule "${prefix}"`,
+      );
+    }
+    if ((rule.username === undefined) !== (rule.accessToken === undefined)) {
+      throw new Error(
+        `[username] and [accessToken] must be provided together for pull-through cache rule "${prefix}"`,
+      );
+    }
+    if (
+      upstreamsRequiringCredentials.includes(rule.upstream) &&
+      rule.credentialArn === undefined &&
+      rule.username === undefined
+    ) {
+      throw new Error(
+        `Pull-through cache rule "${prefix}" requires [credentialArn] or [username] and [accessToken] for ${rule.upstream}`,
+      );
+    }
+
+    let credentialArn = rule.credentialArn;
+    if (rule.username !== undefined) {
+      const secret = new aws.secretsmanager.Secret(
+        `${name}-${prefix}`,
+        {
+          ...regionArgs,
+          // ECR only reads secrets with this prefix.
+          namePrefix: `ecr-pullthroughcache/${prefix}-`,
+        },
+        { parent: this },
+      );
+      const secretVersion = new aws.secretsmanager.SecretVersion(
+        `${name}-${prefix}`,
+        {
+          ...regionArgs,
+          secretId: secret.id,
+          secretString: pulumi.secret(
+            pulumi.jsonStringify({ username: rule.username, accessToken: rule.accessToken }),
+          ),
+        },
+        { parent: this },
+      );
+      credentialArn = secretVersion.arn;
+    }
+
+    return new aws.ecr.PullThroughCacheRule(
+      `${name}-${prefix}`,
+      {
+        ...regionArgs,
+        ecrRepositoryPrefix: prefix,
+        upstreamRegistryUrl: upstreamRegistryUrls[rule.upstream],
+        credentialArn,
+      },
+      { parent: this },
+    );
+  }
+}
+
+/** @internal */
+export function replicaRegistryUrls(
+  accountId: string,
+  sourceRegion: string,
+  dnsSuffix: string,
+  destinations: schema.ReplicationDestinationInputs[],
+): Record<string, string> {
+  const urls: Record<string, string> = {};
+  for (const { region, registryId } of destinations) {
+    const id = registryId ?? accountId;
+    const key = id === accountId ? region : `${id}/${region}`;
+    if (id === accountId && region === sourceRegion) {
+      throw new Error(`Images can't be replicated to the source registry "${key}"`);
+    }
+    if (urls[key] !== undefined) {
+      throw new Error(`Images can only be replicated once to the registry "${key}"`);
+    }
+    urls[key] = `${id}.dkr.ecr.${region}.${dnsSuffix}`;
+  }
+  return urls;
+}
+
+/**
+ * Returns the URLs of the replicas of the repository with the given name, keyed like the replica
+ * registry URLs of the registry.
+ * @internal
+ */
+export function replicaRepositoryUrls(
+  registry: schema.Registry,
+  repositoryName: pulumi.Input<string>,
+): pulumi.Output<Record<string, string>> {
+  return pulumi
+    .all([registry.replicaRegistryUrls, registry.replicatedRepositoryPrefixes, repositoryName])
+    .apply(([urls, prefixes, repositoryName]) => {
+      if (prefixes.length > 0 && !prefixes.some((p) => repositoryName.startsWith(p))) {
+        return {};
+      }
+      return Object.fromEntries(
+        Object.entries(urls).map(([key, url]) => [key, `${url}/${repositoryName}`]),
+      );
+    });
+}
@@ -15,7 +15,7 @@
 import * as runtime from "@pulumi/pulumi/runtime";
 import * as pulumi from "@pulumi/pulumi";
 import * as pulumiAws from "@pulumi/aws";
-import { Repository } from "./repository";
+import { checkRepositoryName, convertRules, Repository } from "./repository";
 
 function unwrap<T>(x: pulumi.Output<T> | T): Promise<T> {
   return new Promise((resolve) => (pulumi.Output.isInstance(x) ? x.apply(resolve) : resolve(x)));
@@ -109,4 +109,201 @@ describe("Repository region passthrough", () => {
     );
     expect("region" in lifecyclePolicy.inputs).toBe(false);
   });
+
+  it("applies the secure-default preset and exposes the resolved settings", async () => {
+    const repo = new Repository("Preset-Repo", { preset: "secure-default" }, {});
+
+    await unwrap(repo.lifecyclePolicy!.id);
+
+    const repository = findCreatedResource("aws:ecr/repository:Repository", "preset-repo");
+    expect(repository.inputs.encryptionConfigurations).toEqual([{ encryptionType: "KMS" }]);
+    expect(repository.inputs.imageTagMutability).toBe("IMMUTABLE");
+    expect(repository.inputs.imageScanningConfiguration).toEqual({ scanOnPush: true });
+
+    const lifecyclePolicy = findCreatedResource(
+      "aws:ecr/lifecyclePolicy:LifecyclePolicy",
+      "preset-repo",
+    );
+    const policy =
+      typeof lifecyclePolicy.inputs.policy === "string"
+        ? JSON.parse(lifecyclePolicy.inputs.policy)
+        : lifecyclePolicy.inputs.policy;
+    expect(policy.rules.map((r: any) => r.description)).toEqual([
+      "expire untagged images after 14 days",
+      "keep the 500 most recent images",
+    ]);
+
+    const settings = repo.settings as any;
+    expect(await unwrap(settings.preset)).toBe("secure-default");
+    expect(await unwrap(settings.encryptionType)).toBe("KMS");
+    expect(await unwrap(settings.imageTagMutability)).toBe("IMMUTABLE");
+    expect(await unwrap(settings.scanOnPush)).toBe(true);
+  });
+
+  it("lets explicit settings take precedence over the preset", async () => {
+    const repo = new Repository(
+      "Preset-Override-Repo",
+      {
+        preset: "secure-default",
+        imageTagMutability: "MUTABLE",
+        lifecyclePolicy: { rules: [{ tagStatus: "untagged", maximumNumberOfImages: 1 }] },
+      },
+      {},
+    );
+
+    await unwrap(repo.lifecyclePolicy!.id);
+
+    const repository = findCreatedResource("aws:ecr/repository:Repository", "preset-override-repo");
+    expect(repository.inputs.imageTagMutability).toBe("MUTABLE");
+    expect(repository.inputs.imageScanningConfiguration).toEqual({ scanOnPush: true });
+    const lifecyclePolicy = findCreatedResource(
+      "aws:ecr/lifecyclePolicy:LifecyclePolicy",
+      "preset-override-repo",
+    );
+    expect(JSON.stringify(lifecyclePolicy.inputs.policy)).not.toContain("500 most recent");
+  });
+
+  it("reports the defaults of a repository without a preset", async () => {
+    const repo = new Repository("Plain-Repo", {}, {});
+
+    const settings = repo.settings as any;
+    expect(settings.preset).toBeUndefined();
+    expect(await unwrap(settings.encryptionType)).toBe("AES256");
+    expect(await unwrap(settings.imageTagMutability)).toBe("MUTABLE");
+    expect(await unwrap(settings.scanOnPush)).toBe(false);
+  });
+
+  it("requires an explicit name with a naming policy", () => {
+    expect(
+      () => new Repository("Payments-API", { namingPolicy: { prefix: "payments/" } }, {}),
+    ).toThrow("[namingPolicy] requires [name]");
+  });
+});
+
+describe("checkRepositoryName", () => {
+  const policy = { prefix: "payments/", pattern: "^[a-z]+/[a-z0-9-]+$" };
+
+  it("accepts names following the policy", () => {
+    expect(() => checkRepositoryName("payments/api-v2", policy)).not.toThrow();
+  });
+
+  it("rejects names without the prefix", () => {
+    expect(() => checkRepositoryName("orders/api", policy)).toThrow(
+      'Repository name "orders/api" must start with "payments/"',
+    );
+  });
+
+  it("rejects names not matching the pattern", () => {
+    expect(() => checkRepositoryName("payments/API", policy)).toThrow(
+      'Repository name "payments/API" must match the pattern ^[a-z]+/[a-z0-9-]+$',
+    );
+  });
+
+  it("rejects invalid patterns", () => {
+    expect(() => checkRepositoryName("payments/api", { pattern: "(" })).toThrow(
+      "[namingPolicy.pattern] is not a valid regular expression",
+    );
+  });
+});
+
+describe("lifecycle policy rules", () => {
+  it("keeps explicit priorities and fills in the rest in order", () => {
+    const policy = convertRules([
+      { tagStatus: "any", maximumNumberOfImages: 100 },
+      { tagStatus: "untagged", maximumAgeLimit: 2, maximumAgeUnit: "weeks" },
+      { tagStatus: "tagged", tagPatternList: ["prod-*"], maximumNumberOfImages: 10, priority: 1 },
+    ]);
+    expect(policy.rules).toEqual([
+      {
+        rulePriority: 2,
+        selection: {
+          tagStatus: "untagged",
+          countType: "sinceImagePushed",
+          countNumber: 14,
+          countUnit: "days",
+        },
+        action: { type: "expire" },
+      },
+      {
+        rulePriority: 1,
+        selection: {
+          tagStatus: "tagged",
+          tagPatternList: ["prod-*"],
+          countType: "imageCountMoreThan",
+          countNumber: 10,
+        },
+        action: { type: "expire" },
+      },
+      {
+        rulePriority: 3,
+        selection: { tagStatus: "any", countType: "imageCountMoreThan", countNumber: 100 },
+        action: { type: "expire" },
+      },
+    ]);
+  });
+
+  it("archives images that haven't been pulled and expires archived images", () => {
+    const policy = convertRules([
+      { tagStatus: "any", maximumAgeLimit: 90, maximumAgeSince: "imagePulled", action: "archive" },
+      {
+        tagStatus: "untagged",
+        maximumAgeLimit: 365,
+        maximumAgeSince: "imageTransitioned",
+        storageClass: "archive",
+        priority: 1,
+      },
+    ]);
+    expect(policy.rules).toMatchObject([
+      {
+        rulePriority: 2,
+        selection: { countType: "sinceImagePulled", countNumber: 90 },
+        action: { type: "transition", targetStorageClass: "archive" },
+      },
+      {
+        rulePriority: 1,
+        selection: { storageClass: "archive", countType: "sinceImageTransitioned" },
+        action: { type: "expire" },
+      },
+    ]);
+  });
+
+  it("rejects tag filters on untagged rules", () => {
+    expect(() =>
+      convertRules([{ tagStatus: "untagged", tagPrefixList: ["v"], maximumNumberOfImages: 1 }]),
+    ).toThrow(
+      "[tagPrefixList] and [tagPatternList] can't be specified with [tagStatus: untagged].",
+    );
+  });
+
+  it("rejects a maximum number of images together with a maximum age", () => {
+    expect(() =>
+      convertRules([{ tagStatus: "any", maximumNumberOfImages: 10, maximumAgeLimit: 30 }]),
+    ).toThrow("Only one of [maximumNumberOfImages] or [maximumAgeLimit] can be specified.");
+  });
+
+  it("rejects duplicate priorities", () => {
+    expect(() =>
+      convertRules([
+        { tagStatus: "untagged", maximumNumberOfImages: 1, priority: 1 },
+        { tagStatus: "tagged", tagPrefixList: ["v"], maximumNumberOfImages: 1, priority: 1 },
+      ]),
+    ).toThrow("Rule [priority] 1 is used more than once.");
+  });
+
+  it("requires the any rule to have the highest priority", () => {
+    expect(() =>
+      convertRules([
+        { tagStatus: "untagged", maximumNumberOfImages: 1, priority: 5 },
+        { tagStatus: "any", maximumNumberOfImages: 1 },
+      ]),
+    ).toThrow('The [selection: "any"] rule must have the highest [priority].');
+  });
+
+  it("limits tag patterns to four wildcards", () => {
+    expect(() =>
+      convertRules([
+        { tagStatus: "tagged", tagPatternList: ["*a*b*c*d*"], maximumNumberOfImages: 1 },
+      ]),
+    ).toThrow('Tag pattern "*a*b*c*d*" contains more than four wildcards.');
+  });
 });
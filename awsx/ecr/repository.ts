@@ -15,6 +15,8 @@
 import * as aws from "@pulumi/aws";
 import * as pulumi from "@pulumi/pulumi";
 import * as schema from "../schema-types";
+import { replicaRepositoryUrls } from "./registry";
+import { createRepositoryPolicy } from "./repositoryPolicy";
 
 export class Repository extends schema.Repository {
   constructor(name: string, args: schema.RepositoryArgs, opts: pulumi.ComponentResourceOptions) {
@@ -23,20 +25,49 @@ export class Repository extends schema.Repository {
       return; // Rehydrating, skip construction
     }
     const lowerCaseName = name.toLowerCase();
-    const { lifecyclePolicy, ...repoArgs } = args;
+    const { lifecyclePolicy, registry, access, preset, namingPolicy, ...repoArgs } = args;
 
-    this.repository = new aws.ecr.Repository(lowerCaseName, repoArgs, {
-      parent: this,
-    });
+    let repositoryName = repoArgs.name;
+    if (namingPolicy !== undefined) {
+      // A generated name gets a random suffix, so only an explicit name can be checked.
+      if (repositoryName === undefined) {
+        throw new Error("[namingPolicy] requires [name]");
+      }
+      repositoryName = pulumi.output(repositoryName).apply((repositoryName) => {
+        checkRepositoryName(repositoryName, namingPolicy);
+        return repositoryName;
+      });
+    }
 
+    this.repository = new aws.ecr.Repository(
+      lowerCaseName,
+      { ...applyPreset(preset, repoArgs), name: repositoryName },
+      { parent: this },
+    );
     this.url = this.repository.repositoryUrl;
+    if (access !== undefined) {
+      this.repositoryPolicy = createRepositoryPolicy(
+        lowerCaseName,
+        this.repository,
+        access,
+        args.region,
+        this,
+      );
+    }
+    if (registry !== undefined) {
+      this.replicaUrls = replicaRepositoryUrls(registry, this.repository.name);
+    }
 
     if (!lifecyclePolicy?.skip) {
       this.lifecyclePolicy = new aws.ecr.LifecyclePolicy(
         lowerCaseName,
         {
           repository: this.repository.name,
-          policy: buildLifecyclePolicy(lifecyclePolicy),
+          policy: buildLifecyclePolicy(
+            lifecyclePolicy?.rules === undefined && preset !== undefined
+              ? repositoryPresets[preset].lifecyclePolicy
+              : lifecyclePolicy,
+          ),
           // Thread the component's top-level region through to the lifecycle
           // policy so it is evaluated in the same region as the repository
           // rather than the ambient provider region (see #1935, #1933).
@@ -48,13 +79,115 @@ export class Repository extends schema.Repository {
       );
     }
 
+    this.settings = resolvedSettings(this.repository, this.lifecyclePolicy, preset);
+
     this.registerOutputs({
       repository: this.repository,
+      settings: this.settings,
       lifecyclePolicy: this.lifecyclePolicy,
+      repositoryPolicy: this.repositoryPolicy,
+      replicaUrls: this.replicaUrls,
     });
   }
 }
 
+type RepositoryResourceArgs = Omit<
+  schema.RepositoryArgs,
+  "lifecyclePolicy" | "registry" | "access" | "preset" | "namingPolicy"
+>;
+
+interface RepositoryPreset {
+  repository: RepositoryResourceArgs;
+  lifecyclePolicy: schema.lifecyclePolicyInputs;
+}
+
+const repositoryPresets: Record<schema.RepositoryPresetInputs, RepositoryPreset> = {
+  "secure-default": {
+    repository: {
+      // Without a key, ECR uses the AWS managed key for ECR.
+      encryptionConfigurations: [{ encryptionType: "KMS" }],
+      imageTagMutability: "IMMUTABLE",
+      imageScanningConfiguration: { scanOnPush: true },
+    },
+    lifecyclePolicy: {
+      rules: [
+        {
+          description: "expire untagged images after 14 days",
+          tagStatus: "untagged",
+          maximumAgeLimit: 14,
+        },
+        {
+          description: "keep the 500 most recent images",
+          tagStatus: "any",
+          maximumNumberOfImages: 500,
+        },
+      ],
+    },
+  },
+};
+
+/**
+ * Fills in the settings of the preset that weren't provided explicitly.
+ * @internal
+ */
+export function applyPreset(
+  preset: schema.RepositoryPresetInputs | undefined,
+  args: RepositoryResourceArgs,
+): RepositoryResourceArgs {
+  if (preset === undefined) {
+    return args;
+  }
+  if (repositoryPresets[preset] === undefined) {
+    throw new Error(`Unknown repository [preset] "${preset}"`);
+  }
+  const defaults = repositoryPresets[preset].repository;
+  return {
+    ...args,
+    encryptionConfigurations: args.encryptionConfigurations ?? defaults.encryptionConfigurations,
+    imageTagMutability: args.imageTagMutability ?? defaults.imageTagMutability,
+    imageScanningConfiguration:
+      args.imageScanningConfiguration ?? defaults.imageScanningConfiguration,
+  };
+}
+
+/**
+ * Throws if the repository name doesn't follow the naming policy.
+ * @internal
+ */
+export function checkRepositoryName(name: string, policy: schema.RepositoryNamingPolicyInputs) {
+  const { prefix, pattern } = policy;
+  if (prefix !== undefined && !name.startsWith(prefix)) {
+    throw new Error(`Repository name "${name}" must start with "${prefix}"`);
+  }
+  if (pattern !== undefined) {
+    let regex: RegExp;
+    try {
+      regex = new RegExp(pattern);
+    } catch (e) {
+      throw new Error(`[namingPolicy.pattern] is not a valid regular expression: ${e}`);
+    }
+    if (!regex.test(name)) {
+      throw new Error(`Repository name "${name}" must match the pattern ${pattern}`);
+    }
+  }
+}
+
+function resolvedSettings(
+  repository: aws.ecr.Repository,
+  lifecyclePolicy: aws.ecr.LifecyclePolicy | undefined,
+  preset: schema.RepositoryPresetInputs | undefined,
+): schema.RepositorySettingsOutputs {
+  const encryption = repository.encryptionConfigurations.apply((configs) => configs?.[0]);
+  return {
+    preset: preset !== undefined ? pulumi.output(preset) : undefined,
+    encryptionType: encryption.apply((e) => e?.encryptionType ?? "AES256"),
+    kmsKey: encryption.apply((e) => e?.kmsKey || undefined) as pulumi.Output<string>,
+    imageTagMutability: repository.imageTagMutability.apply((m) => m ?? "MUTABLE"),
+    scanOnPush: repository.imageScanningConfiguration.apply((c) => c?.scanOnPush ?? false),
+    lifecyclePolicy: lifecyclePolicy?.policy,
+  };
+}
+
 function buildLifecyclePolicy(
   lifecyclePolicy: schema.lifecyclePolicyInputs | undefined,
 ): pulumi.Input<aws.types.input.ecr.LifecyclePolicyDocument> {
@@ -71,12 +204,31 @@ function buildLifecyclePolicy(
   return pulumi.output(rules).apply((rules) => convertRules(rules));
 }
 
-function convertRules(
+// The typed policy document doesn't cover archive transitions yet, so rules are described here
+// and converted to the typed document once complete.
+interface PolicyRule {
+  rulePriority: number;
+  description?: string;
+  selection: {
+    tagStatus: "any" | "tagged" | "untagged";
+    tagPrefixList?: string[];
+    tagPatternList?: string[];
+    storageClass?: "standard" | "archive";
+    countType:
+      | "imageCountMoreThan"
+      | "sinceImagePushed"
+      | "sinceImagePulled"
+      | "sinceImageTransitioned";
+    countUnit?: "days";
+    countNumber: number;
+  };
+  action: { type: "expire" } | { type: "transition"; targetStorageClass: "archive" };
+}
+
+/** @internal */
+export function convertRules(
   rules: pulumi.Unwrap<schema.lifecyclePolicyRuleInputs>[],
 ): aws.types.input.ecr.LifecyclePolicyDocument {
-  const result: aws.types.input.ecr.LifecyclePolicyDocument = { rules: [] };
-  const documentRules: aws.types.input.ecr.LifecyclePolicyRule[] = [];
-
   const nonAnyRules = rules.filter((r) => r.tagStatus !== "any");
   const anyRules = rules.filter((r) => r.tagStatus === "any");
 
@@ -87,28 +239,80 @@ function convertRules(
   // Place the 'any' rule last so it has higest priority.
   const orderedRules = [...nonAnyRules, ...anyRules];
 
-  let rulePriority = 1;
+  const explicitPriorities = new Set<number>();
   for (const rule of orderedRules) {
-    documentRules.push(convertRule(rule, rulePriority));
-    rulePriority++;
+    if (rule.priority === undefined) {
+      continue;
+    }
+    if (explicitPriorities.has(rule.priority)) {
+      throw new Error(`Rule [priority] ${rule.priority} is used more than once.`);
+    }
+    explicitPriorities.add(rule.priority);
+  }
+
+  let rulePriority = 1;
+  const documentRules = orderedRules.map((rule) => {
+    if (rule.priority !== undefined) {
+      return convertRule(rule, rule.priority);
+    }
+    while (explicitPriorities.has(rulePriority)) {
+      rulePriority++;
+    }
+    return convertRule(rule, rulePriority++);
+  });
+
+  const anyRule = documentRules.find((r) => r.selection.tagStatus === "any");
+  if (anyRule && documentRules.some((r) => r.rulePriority > anyRule.rulePriority)) {
+    throw new Error(`The [selection: "any"] rule must have the highest [priority].`);
   }
-  result.rules = documentRules;
 
-  return result;
+  return { rules: documentRules } as aws.types.input.ecr.LifecyclePolicyDocument;
 }
 
+const countTypes = {
+  imagePushed: "sinceImagePushed",
+  imagePulled: "sinceImagePulled",
+  imageTransitioned: "sinceImageTransitioned",
+} as const;
+
 function convertRule(
   rule: pulumi.Unwrap<schema.lifecyclePolicyRuleInputs>,
   rulePriority: number,
-): aws.types.input.ecr.LifecyclePolicyRule {
+): PolicyRule {
+  if (rule.maximumAgeSince === "imageTransitioned" && rule.storageClass !== "archive") {
+    throw new Error(
+      "[maximumAgeSince: imageTransitioned] can only be specified with [storageClass: archive].",
+    );
+  }
+  if (rule.action === "archive" && rule.storageClass === "archive") {
+    throw new Error("[action: archive] can't be specified with [storageClass: archive].");
+  }
+
   return {
     rulePriority,
     description: rule.description,
-    selection: { ...convertTag(), ...convertCount() },
-    action: { type: "expire" },
+    selection: {
+      ...convertTag(),
+      ...(rule.storageClass !== undefined ? { storageClass: rule.storageClass } : {}),
+      ...convertCount(),
+    },
+    action:
+      rule.action === "archive"
+        ? { type: "transition", targetStorageClass: "archive" }
+        : { type: "expire" },
   };
 
   function convertCount() {
+    if (rule.maximumNumberOfImages !== undefined && rule.maximumAgeLimit !== undefined) {
+      throw new Error("Only one of [maximumNumberOfImages] or [maximumAgeLimit] can be specified.");
+    }
+    if (rule.maximumAgeLimit === undefined && rule.maximumAgeUnit !== undefined) {
+      throw new Error("[maximumAgeUnit] can only be specified with [maximumAgeLimit].");
+    }
+    if (rule.maximumAgeLimit === undefined && rule.maximumAgeSince !== undefined) {
+      throw new Error("[maximumAgeSince] can only be specified with [maximumAgeLimit].");
+    }
+
     if (rule.maximumNumberOfImages !== undefined) {
       return {
         countType: "imageCountMoreThan",
@@ -117,8 +321,8 @@ function convertRule(
       } as const;
     } else if (rule.maximumAgeLimit !== undefined) {
       return {
-        countType: "sinceImagePushed",
-        countNumber: rule.maximumAgeLimit,
+        countType: countTypes[rule.maximumAgeSince ?? "imagePushed"],
+        countNumber: rule.maximumAgeLimit * (rule.maximumAgeUnit === "weeks" ? 7 : 1),
         countUnit: "days",
       } as const;
     } else {
@@ -130,8 +334,30 @@ function convertRule(
 
   function convertTag() {
     if (rule.tagStatus === "any" || rule.tagStatus === "untagged") {
+      if (rule.tagPrefixList !== undefined || rule.tagPatternList !== undefined) {
+        throw new Error(
+          `[tagPrefixList] and [tagPatternList] can't be specified with [tagStatus: ${rule.tagStatus}].`,
+        );
+      }
       return { tagStatus: rule.tagStatus };
     } else {
+      if (rule.tagPrefixList !== undefined && rule.tagPatternList !== undefined) {
+        throw new Error("Only one of [tagPrefixList] or [tagPatternList] can be specified.");
+      }
+      if (rule.tagPatternList !== undefined) {
+        if (rule.tagPatternList.length === 0) {
+          throw new Error("tagPatternList cannot be empty.");
+        }
+        for (const pattern of rule.tagPatternList) {
+          if (pattern.split("*").length - 1 > 4) {
+            throw new Error(`Tag pattern "${pattern}" contains more than four wildcards.`);
+          }
+        }
+        return {
+          tagStatus: "tagged",
+          tagPatternList: rule.tagPatternList,
+        } as const;
+      }
       if (!rule.tagPrefixList || rule.tagPrefixList.length === 0) {
         throw new Error("tagPrefixList cannot be empty.");
       }
@@ -0,0 +1,88 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import { repositoryPolicyDocument } from "./repositoryPolicy";
+
+describe("repositoryPolicyDocument", () => {
+  it("grants pull to accounts and organizations and push to CI", () => {
+    const document = repositoryPolicyDocument(
+      {
+        pull: { accountIds: ["111111111111"], organizationIds: ["o-abc123"] },
+        push: { principals: ["arn:aws:iam::222222222222:role/ci"] },
+      },
+      "aws",
+    );
+    expect(document.Statement).toEqual([
+      {
+        Sid: "PullPrincipals",
+        Effect: "Allow",
+        Principal: { AWS: ["arn:aws:iam::111111111111:root"] },
+        Action: [
+          "ecr:BatchCheckLayerAvailability",
+          "ecr:BatchGetImage",
+          "ecr:GetDownloadUrlForLayer",
+        ],
+      },
+      {
+        Sid: "PullOrganizations",
+        Effect: "Allow",
+        Principal: "*",
+        Action: [
+          "ecr:BatchCheckLayerAvailability",
+          "ecr:BatchGetImage",
+          "ecr:GetDownloadUrlForLayer",
+        ],
+        Condition: { StringEquals: { "aws:PrincipalOrgID": ["o-abc123"] } },
+      },
+      {
+        Sid: "PushPrincipals",
+        Effect: "Allow",
+        Principal: { AWS: ["arn:aws:iam::222222222222:role/ci"] },
+        Action: [
+          "ecr:BatchCheckLayerAvailability",
+          "ecr:CompleteLayerUpload",
+          "ecr:InitiateLayerUpload",
+          "ecr:PutImage",
+          "ecr:UploadLayerPart",
+        ],
+      },
+    ]);
+  });
+
+  it("lets Lambda pull for the given functions", () => {
+    const document = repositoryPolicyDocument(
+      { lambda: { sourceArns: ["arn:aws-cn:lambda:cn-north-1:111111111111:function:*"] } },
+      "aws-cn",
+    );
+    expect(document.Statement).toEqual([
+      {
+        Sid: "LambdaPull",
+        Effect: "Allow",
+        Principal: { Service: "lambda.amazonaws.com" },
+        Action: ["ecr:BatchGetImage", "ecr:GetDownloadUrlForLayer"],
+        Condition: {
+          StringLike: {
+            "aws:sourceArn": ["arn:aws-cn:lambda:cn-north-1:111111111111:function:*"],
+          },
+        },
+      },
+    ]);
+  });
+
+  it("rejects grants without principals", () => {
+    expect(() => repositoryPolicyDocument({ pull: {} }, "aws")).toThrow(
+      "At least one of [accountIds], [organizationIds] or [principals] must be specified in [pull]",
+    );
+  });
+});
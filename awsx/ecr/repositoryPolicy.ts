@@ -0,0 +1,126 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import * as aws from "@pulumi/aws";
+import * as pulumi from "@pulumi/pulumi";
+import * as schema from "../schema-types";
+
+const pullActions = [
+  "ecr:BatchCheckLayerAvailability",
+  "ecr:BatchGetImage",
+  "ecr:GetDownloadUrlForLayer",
+];
+
+const pushActions = [
+  "ecr:BatchCheckLayerAvailability",
+  "ecr:CompleteLayerUpload",
+  "ecr:InitiateLayerUpload",
+  "ecr:PutImage",
+  "ecr:UploadLayerPart",
+];
+
+/**
+ * Creates the repository policy granting the given access to the repository.
+ * @internal
+ */
+export function createRepositoryPolicy(
+  name: string,
+  repository: aws.ecr.Repository,
+  access: schema.RepositoryAccessInputs,
+  region: pulumi.Input<string> | undefined,
+  parent: pulumi.Resource,
+): aws.ecr.RepositoryPolicy {
+  if (access.pull === undefined && access.push === undefined && access.lambda === undefined) {
+    throw new Error("At least one of [pull], [push] or [lambda] must be specified in [access]");
+  }
+  const partition = aws.getPartitionOutput({}, { parent }).partition;
+  return new aws.ecr.RepositoryPolicy(
+    name,
+    {
+      repository: repository.name,
+      policy: pulumi
+        .all([pulumi.output(access), partition])
+        .apply(([access, partition]) =>
+          JSON.stringify(repositoryPolicyDocument(access, partition)),
+        ),
+      ...(region !== undefined ? { region } : {}),
+    },
+    { parent },
+  );
+}
+
+/** @internal */
+export function repositoryPolicyDocument(
+  access: pulumi.Unwrap<schema.RepositoryAccessInputs>,
+  partition: string,
+) {
+  const statements = [
+    ...grantStatements("Pull", pullActions, access.pull, partition),
+    ...grantStatements("Push", pushActions, access.push, partition),
+  ];
+  if (access.lambda !== undefined) {
+    if (access.lambda.sourceArns.length === 0) {
+      throw new Error("[lambda.sourceArns] cannot be empty");
+    }
+    statements.push({
+      Sid: "LambdaPull",
+      Effect: "Allow",
+      Principal: { Service: "lambda.amazonaws.com" },
+      Action: ["ecr:BatchGetImage", "ecr:GetDownloadUrlForLayer"],
+      Condition: { StringLike: { "aws:sourceArn": access.lambda.sourceArns } },
+    });
+  }
+  return { Version: "2012-10-17", Statement: statements };
+}
+
+function grantStatements(
+  sidPrefix: string,
+  actions: string[],
+  grant: pulumi.Unwrap<schema.RepositoryAccessGrantInputs> | undefined,
+  partition: string,
+): Record<string, any>[] {
+  if (grant === undefined) {
+    return [];
+  }
+  const { accountIds = [], organizationIds = [], principals = [] } = grant;
+  if (accountIds.length + organizationIds.length + principals.length === 0) {
+    throw new Error(
+      `At least one of [accountIds], [organizationIds] or [principals] must be specified in [${sidPrefix.toLowerCase()}]`,
+    );
+  }
+
+  const statements: Record<string, any>[] = [];
+  const awsPrincipals = [
+    ...accountIds.map((id) => `arn:${partition}:iam::${id}:root`),
+    ...principals,
+  ];
+  if (awsPrincipals.length > 0) {
+    statements.push({
+      Sid: `${sidPrefix}Principals`,
+      Effect: "Allow",
+      Principal: { AWS: awsPrincipals },
+      Action: actions,
+    });
+  }
+  if (organizationIds.length > 0) {
+    statements.push({
+      Sid: `${sidPrefix}Organizations`,
+      Effect: "Allow",
+      Principal: "*",
+      Action: actions,
+      Condition: { StringEquals: { "aws:PrincipalOrgID": organizationIds } },
+    });
+  }
+  return statements;
+}
@@ -0,0 +1,168 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import {
+  gateOnScanFindings,
+  ScanFindingsClient,
+  ScanFindingsPage,
+  summarizeScanFindings,
+  waitForScanFindings,
+} from "./scanGate";
+
+// A stand-in for the ECR API returning the given responses in order.
+function fakeClient(responses: (ScanFindingsPage | Error)[]) {
+  const requests: any[] = [];
+  const client: ScanFindingsClient = {
+    describeImageScanFindings: async (request) => {
+      requests.push(request);
+      const response = responses.shift();
+      if (response === undefined) {
+        throw new Error("unexpected request");
+      }
+      if (response instanceof Error) {
+        throw response;
+      }
+      return response;
+    },
+  };
+  return { client, requests };
+}
+
+function scanNotFound(): Error {
+  return Object.assign(new Error("scan not found"), { name: "ScanNotFoundException" });
+}
+
+const request = {
+  registryId: "123456789012",
+  repositoryName: "app",
+  imageId: { imageDigest: "sha256:abc" },
+};
+const noSleep = async () => {};
+
+describe("waitForScanFindings", () => {
+  it("polls until the scan is complete", async () => {
+    const { client, requests } = fakeClient([
+      scanNotFound(),
+      { imageScanStatus: { status: "IN_PROGRESS" } },
+      {
+        imageScanStatus: { status: "COMPLETE" },
+        imageScanFindings: { findings: [{ name: "CVE-2024-0001", severity: "HIGH" }] },
+      },
+    ]);
+    const findings = await waitForScanFindings(client, request, 60_000, noSleep);
+    expect(findings).toEqual([{ id: "CVE-2024-0001", severity: "HIGH" }]);
+    expect(requests).toHaveLength(3);
+  });
+
+  it("follows pagination and reads enhanced findings", async () => {
+    const { client, requests } = fakeClient([
+      {
+        imageScanStatus: { status: "ACTIVE" },
+        imageScanFindings: {
+          enhancedFindings: [
+            { severity: "CRITICAL", packageVulnerabilityDetails: { vulnerabilityId: "CVE-1" } },
+          ],
+        },
+        nextToken: "page-2",
+      },
+      {
+        imageScanStatus: { status: "ACTIVE" },
+        imageScanFindings: {
+          enhancedFindings: [
+            { severity: "LOW", packageVulnerabilityDetails: { vulnerabilityId: "CVE-2" } },
+          ],
+        },
+      },
+    ]);
+    const findings = await waitForScanFindings(client, request, 60_000, noSleep);
+    expect(findings).toEqual([
+      { id: "CVE-1", severity: "CRITICAL" },
+      { id: "CVE-2", severity: "LOW" },
+    ]);
+    expect(requests[1].nextToken).toBe("page-2");
+  });
+
+  it("times out when the scan doesn't finish", async () => {
+    const { client } = fakeClient(Array(4).fill({ imageScanStatus: { status: "IN_PROGRESS" } }));
+    await expect(waitForScanFindings(client, request, 10_000, noSleep)).rejects.toThrow(
+      "Timed out after 10 seconds waiting for the scan of app@sha256:abc",
+    );
+  });
+
+  it("fails when the scan fails", async () => {
+    const { client } = fakeClient([
+      { imageScanStatus: { status: "FAILED", description: "unsupported image" } },
+    ]);
+    await expect(waitForScanFindings(client, request, 60_000, noSleep)).rejects.toThrow(
+      "The scan of app@sha256:abc ended with status FAILED: unsupported image",
+    );
+  });
+
+  it("rethrows other errors", async () => {
+    const { client } = fakeClient([new Error("access denied")]);
+    await expect(waitForScanFindings(client, request, 60_000, noSleep)).rejects.toThrow(
+      "access denied",
+    );
+  });
+});
+
+describe("summarizeScanFindings", () => {
+  const findings = [
+    { id: "CVE-1", severity: "CRITICAL" },
+    { id: "CVE-2", severity: "HIGH" },
+    { id: "CVE-2", severity: "HIGH" },
+    { id: "CVE-3", severity: "MEDIUM" },
+    { id: "CVE-4", severity: "UNDEFINED" },
+  ];
+
+  it("blocks findings at or above the default threshold of HIGH", () => {
+    expect(summarizeScanFindings(findings, {})).toEqual({
+      severityCounts: { CRITICAL: 1, HIGH: 2, MEDIUM: 1, UNDEFINED: 1 },
+      blockingVulnerabilities: ["CVE-1", "CVE-2"],
+      allowedVulnerabilities: [],
+    });
+  });
+
+  it("honors the threshold and allowed vulnerabilities", () => {
+    const summary = summarizeScanFindings(findings, {
+      severityThreshold: "MEDIUM",
+      allowedVulnerabilities: ["CVE-2", "CVE-9"],
+    });
+    expect(summary.blockingVulnerabilities).toEqual(["CVE-1", "CVE-3"]);
+    expect(summary.allowedVulnerabilities).toEqual(["CVE-2"]);
+  });
+});
+
+describe("gateOnScanFindings", () => {
+  const repositoryUrl = "123456789012.dkr.ecr.us-west-2.amazonaws.com/app";
+  const complete = (severity: string): ScanFindingsPage => ({
+    imageScanStatus: { status: "COMPLETE" },
+    imageScanFindings: { findings: [{ name: "CVE-1", severity }] },
+  });
+
+  it("fails on findings at or above the threshold", async () => {
+    const { client, requests } = fakeClient([complete("CRITICAL")]);
+    await expect(
+      gateOnScanFindings(repositoryUrl, undefined, "sha256:abc", {}, client),
+    ).rejects.toThrow(
+      `The scan of ${repositoryUrl}@sha256:abc found vulnerabilities of severity HIGH or higher: CVE-1.`,
+    );
+    expect(requests[0]).toEqual(request);
+  });
+
+  it("passes on findings below the threshold", async () => {
+    const { client } = fakeClient([complete("MEDIUM")]);
+    await gateOnScanFindings(repositoryUrl, undefined, "sha256:abc", {}, client);
+  });
+});
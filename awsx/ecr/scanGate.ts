@@ -0,0 +1,294 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import { DescribeImageScanFindingsCommand, ECRClient } from "@aws-sdk/client-ecr";
+import * as aws from "@pulumi/aws";
+import * as pulumi from "@pulumi/pulumi";
+import * as schema from "../schema-types";
+import { parseRepositoryUrl } from "./immutableTags";
+
+/** The subset of the ECR DescribeImageScanFindings API the scan gate uses. */
+export interface ScanFindingsClient {
+  describeImageScanFindings(request: {
+    registryId: string;
+    repositoryName: string;
+    imageId: { imageDigest: string };
+    nextToken?: string;
+  }): Promise<ScanFindingsPage>;
+}
+
+/** A page of the DescribeImageScanFindings response. */
+export interface ScanFindingsPage {
+  imageScanStatus?: { status?: string; description?: string };
+  imageScanFindings?: {
+    findings?: { name?: string; severity?: string }[];
+    enhancedFindings?: {
+      severity?: string;
+      packageVulnerabilityDetails?: { vulnerabilityId?: string };
+    }[];
+  };
+  nextToken?: string;
+}
+
+export interface ScanFindingsSummary {
+  severityCounts: Record<string, number>;
+  blockingVulnerabilities: string[];
+  allowedVulnerabilities: string[];
+}
+
+const severities: schema.ScanSeverityInputs[] = [
+  "INFORMATIONAL",
+  "LOW",
+  "MEDIUM",
+  "HIGH",
+  "CRITICAL",
+];
+
+const pollIntervalMs = 5000;
+
+/**
+ * Creates a client for the ECR API of the region of the repository, using the credentials of the
+ * AWS provider configuration. The `AWS_ENDPOINT_URL_ECR` environment variable points the client at
+ * a local stand-in for the ECR API.
+ * @internal
+ */
+export function ecrClient(region: string | undefined): ECRClient {
+  const accessKeyId = aws.config.accessKey;
+  const secretAccessKey = aws.config.secretKey;
+  return new ECRClient({
+    region: region ?? aws.config.region,
+    profile: aws.config.profile,
+    credentials:
+      accessKeyId !== undefined && secretAccessKey !== undefined
+        ? { accessKeyId, secretAccessKey, sessionToken: aws.config.token }
+        : undefined,
+    endpoint: process.env.AWS_ENDPOINT_URL_ECR,
+  });
+}
+
+function defaultScanFindingsClient(region: string | undefined): ScanFindingsClient {
+  const ecr = ecrClient(region);
+  return {
+    describeImageScanFindings: (request) =>
+      ecr.send(new DescribeImageScanFindingsCommand(request)),
+  };
+}
+
+/**
+ * Creates hooks to run after an image is pushed. They wait for the scan of the pushed digest to
+ * finish and fail the deployment if it found vulnerabilities at or above the severity threshold
+ * that aren't allowed. Updates that keep the digest don't run the gate again.
+ * @internal
+ */
+export function scanGateHooks(
+  name: string,
+  repositoryUrl: string,
+  registryId: string | undefined,
+  gate: pulumi.Unwrap<schema.ScanGateInputs>,
+  client?: ScanFindingsClient,
+): pulumi.ResourceHookBinding {
+  const hook = new pulumi.ResourceHook(`${name}-scan-gate`, async (args) => {
+    const digest: string | undefined = args.newOutputs?.digest;
+    if (!digest || digest === args.oldOutputs?.digest) {
+      return;
+    }
+    await gateOnScanFindings(repositoryUrl, registryId, digest, gate, client);
+  });
+  return { afterCreate: [hook], afterUpdate: [hook] };
+}
+
+/**
+ * Fails if the scan of the image digest found vulnerabilities at or above the severity threshold
+ * that aren't allowed.
+ * @internal
+ */
+export async function gateOnScanFindings(
+  repositoryUrl: string,
+  registryId: string | undefined,
+  digest: string,
+  gate: pulumi.Unwrap<schema.ScanGateInputs>,
+  client?: ScanFindingsClient,
+): Promise<void> {
+  const summary = summarizeScanFindings(
+    await readScanFindings(repositoryUrl, registryId, [digest], gate, client),
+    gate,
+  );
+  if (summary.blockingVulnerabilities.length > 0) {
+    throw new Error(
+      `The scan of ${repositoryUrl}@${digest} found vulnerabilities of severity ` +
+        `${gate.severityThreshold ?? "HIGH"} or higher: ` +
+        `${summary.blockingVulnerabilities.join(", ")}. ` +
+        "Fix them or add them to [scanGate.allowedVulnerabilities].",
+    );
+  }
+}
+
+/**
+ * Summarizes the scan findings of each of the image digests. Skipped during previews, as nothing
+ * has been pushed yet.
+ * @internal
+ */
+export function scanFindingsSummary(
+  repositoryUrl: string,
+  registryId: string | undefined,
+  digests: pulumi.Input<string[]>,
+  gate: pulumi.Unwrap<schema.ScanGateInputs>,
+  client?: ScanFindingsClient,
+): pulumi.Output<ScanFindingsSummary | undefined> {
+  return pulumi.output(digests).apply(async (digests) => {
+    if (pulumi.runtime.isDryRun()) {
+      return undefined;
+    }
+    const findings = await readScanFindings(repositoryUrl, registryId, digests, gate, client);
+    return summarizeScanFindings(findings, gate);
+  });
+}
+
+async function readScanFindings(
+  repositoryUrl: string,
+  registryId: string | undefined,
+  digests: string[],
+  gate: pulumi.Unwrap<schema.ScanGateInputs>,
+  client?: ScanFindingsClient,
+): Promise<ScannedFinding[]> {
+  const parsed = parseRepositoryUrl(repositoryUrl);
+  const { repositoryName, region } = parsed;
+  const scanClient = client ?? defaultScanFindingsClient(region);
+  const findings: ScannedFinding[] = [];
+  for (const imageDigest of digests) {
+    findings.push(
+      ...(await waitForScanFindings(
+        scanClient,
+        { registryId: registryId ?? parsed.registryId, repositoryName, imageId: { imageDigest } },
+        (gate.timeoutSeconds ?? 600) * 1000,
+      )),
+    );
+  }
+  return findings;
+}
+
+/** @internal */
+export interface ScannedFinding {
+  id: string;
+  severity: string;
+}
+
+/**
+ * Polls the scan findings of an image until the scan has finished and returns all findings.
+ * @internal
+ */
+export async function waitForScanFindings(
+  client: ScanFindingsClient,
+  request: { registryId: string; repositoryName: string; imageId: { imageDigest: string } },
+  timeoutMs: number,
+  sleep: (ms: number) => Promise<void> = (ms) => new Promise((r) => setTimeout(r, ms)),
+): Promise<ScannedFinding[]> {
+  const image = `${request.repositoryName}@${request.imageId.imageDigest}`;
+  let waitedMs = 0;
+  for (;;) {
+    let page: ScanFindingsPage | undefined;
+    try {
+      page = await client.describeImageScanFindings(request);
+    } catch (e) {
+      // The scan of a freshly pushed image may not have started yet.
+      if ((e as { name?: string }).name !== "ScanNotFoundException") {
+        throw e;
+      }
+    }
+
+    const status = page?.imageScanStatus?.status;
+    if (status === "COMPLETE" || status === "ACTIVE") {
+      return collectFindings(client, request, page!);
+    }
+    if (status !== undefined && status !== "IN_PROGRESS" && status !== "PENDING") {
+      const description = page?.imageScanStatus?.description;
+      throw new Error(
+        `The scan of ${image} ended with status ${status}` +
+          (description ? `: ${description}` : ""),
+      );
+    }
+    if (waitedMs >= timeoutMs) {
+      throw new Error(
+        `Timed out after ${timeoutMs / 1000} seconds waiting for the scan of ${image}. ` +
+          "Check that the repository scans images on push or raise [scanGate.timeoutSeconds].",
+      );
+    }
+    await sleep(pollIntervalMs);
+    waitedMs += pollIntervalMs;
+  }
+}
+
+async function collectFindings(
+  client: ScanFindingsClient,
+  request: { registryId: string; repositoryName: string; imageId: { imageDigest: string } },
+  firstPage: ScanFindingsPage,
+): Promise<ScannedFinding[]> {
+  const findings: ScannedFinding[] = [];
+  let page: ScanFindingsPage = firstPage;
+  for (;;) {
+    for (const f of page.imageScanFindings?.findings ?? []) {
+      findings.push({ id: f.name ?? "unknown", severity: f.severity ?? "UNDEFINED" });
+    }
+    for (const f of page.imageScanFindings?.enhancedFindings ?? []) {
+      findings.push({
+        id: f.packageVulnerabilityDetails?.vulnerabilityId ?? "unknown",
+        severity: f.severity ?? "UNDEFINED",
+      });
+    }
+    if (page.nextToken === undefined) {
+      return findings;
+    }
+    page = await client.describeImageScanFindings({ ...request, nextToken: page.nextToken });
+  }
+}
+
+/**
+ * Counts the findings by severity and splits the ones at or above the threshold into blocking
+ * and allowed vulnerabilities.
+ * @internal
+ */
+export function summarizeScanFindings(
+  findings: ScannedFinding[],
+  gate: pulumi.Unwrap<schema.ScanGateInputs>,
+): ScanFindingsSummary {
+  const threshold = severities.indexOf(gate.severityThreshold ?? "HIGH");
+  const allowList = new Set(gate.allowedVulnerabilities ?? []);
+  const severityCounts: Record<string, number> = {};
+  const blocking = new Set<string>();
+  const allowed = new Set<string>();
+  for (const { id, severity } of findings) {
+    severityCounts[severity] = (severityCounts[severity] ?? 0) + 1;
+    // Severities outside the known scale, like UNDEFINED or UNTRIAGED, never block.
+    if (severities.indexOf(severity as schema.ScanSeverityInputs) < threshold) {
+      continue;
+    }
+    (allowList.has(id) ? allowed : blocking).add(id);
+  }
+  return {
+    severityCounts,
+    blockingVulnerabilities: [...blocking].sort(),
+    allowedVulnerabilities: [...allowed].sort(),
+  };
+}
+
+/** @internal */
+export function toScanFindingsSummaryOutputs(
+  summary: ScanFindingsSummary,
+): schema.ScanFindingsSummaryOutputs {
+  return {
+    severityCounts: pulumi.output(summary.severityCounts),
+    blockingVulnerabilities: pulumi.output(summary.blockingVulnerabilities),
+    allowedVulnerabilities: pulumi.output(summary.allowedVulnerabilities),
+  };
+}
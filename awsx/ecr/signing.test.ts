@@ -0,0 +1,68 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import * as pulumi from "@pulumi/pulumi";
+
+import { ImageSigner, signImage } from "./signing";
+
+function promiseOf<T>(output: pulumi.Input<T> | undefined): Promise<T> {
+  return new Promise((resolve) => pulumi.output(output!).apply(resolve));
+}
+
+pulumi.runtime.setMocks({
+  newResource: (args: pulumi.runtime.MockResourceArgs) => ({ id: `${args.name}-id`, state: {} }),
+  call: (args: pulumi.runtime.MockCallArgs) => ({
+    userName: "AWS",
+    password: "password",
+    proxyEndpoint: `https://${args.inputs.registryId}.dkr.ecr.us-west-2.amazonaws.com`,
+  }),
+});
+
+// A local stand-in for the signing CLIs recording what it signed.
+class FakeSigner implements ImageSigner {
+  signed: string[] = [];
+
+  async sign(imageRef: string) {
+    this.signed.push(imageRef);
+    return `${imageRef}.sig`;
+  }
+}
+
+const repositoryUrl = "111111111111.dkr.ecr.us-west-2.amazonaws.com/app";
+const parent = new pulumi.ComponentResource("test:index:Parent", "parent");
+
+describe("signImage", () => {
+  it("signs the pushed digest", async () => {
+    const signer = new FakeSigner();
+    const signatureRefs = await promiseOf(
+      signImage(
+        repositoryUrl,
+        undefined,
+        "sha256:index",
+        { kmsKeyArn: "arn:aws:kms:us-west-2:111111111111:key/abc" },
+        parent,
+        signer,
+      ),
+    );
+
+    expect(signer.signed).toEqual([`${repositoryUrl}@sha256:index`]);
+    expect(signatureRefs).toEqual([`${repositoryUrl}@sha256:index.sig`]);
+  });
+
+  it("requires exactly one signing method", () => {
+    expect(() => signImage(repositoryUrl, undefined, "sha256:image", {}, parent)).toThrow(
+      "Exactly one of [signingProfileArn] or [kmsKeyArn] must be specified in [signing]",
+    );
+  });
+});
@@ -0,0 +1,180 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import * as childProcess from "child_process";
+import * as fs from "fs";
+import * as os from "os";
+import * as path from "path";
+import { promisify } from "util";
+import * as pulumi from "@pulumi/pulumi";
+import * as schema from "../schema-types";
+import { DockerCredentials, getDockerCredentials } from "./auth";
+
+const execFile = promisify(childProcess.execFile);
+
+/**
+ * Signs pushed images. Signing is pluggable so that a local fake can stand in for the CLIs in
+ * tests.
+ */
+export interface ImageSigner {
+  /** Signs the image and returns a reference to the signature. */
+  sign(imageRef: string): Promise<string>;
+}
+
+/**
+ * Signs the pushed image. Skipped during previews, as nothing has been pushed yet.
+ * @internal
+ */
+export function signImage(
+  repositoryUrl: string,
+  registryId: string | undefined,
+  digest: string | undefined,
+  signing: pulumi.Unwrap<schema.ImageSigningInputs>,
+  parent: pulumi.Resource,
+  signer?: ImageSigner,
+): pulumi.Output<string[]> {
+  if ((signing.signingProfileArn === undefined) === (signing.kmsKeyArn === undefined)) {
+    throw new Error(
+      "Exactly one of [signingProfileArn] or [kmsKeyArn] must be specified in [signing]",
+    );
+  }
+  if (pulumi.runtime.isDryRun()) {
+    return pulumi.output([]);
+  }
+
+  if (digest === undefined) {
+    throw new Error(
+      `The digest of the image pushed to ${repositoryUrl} is unknown, so it can't be signed`,
+    );
+  }
+  const credentials = getDockerCredentials({ repositoryUrl, registryId }, { parent });
+  return credentials.apply((credentials) =>
+    withDockerConfig(credentials, async (env) => [
+      await (signer ?? defaultSigner(signing, env)).sign(`${repositoryUrl}@${digest}`),
+    ]),
+  );
+}
+
+function defaultSigner(
+  signing: pulumi.Unwrap<schema.ImageSigningInputs>,
+  env: NodeJS.ProcessEnv,
+): ImageSigner {
+  return signing.kmsKeyArn !== undefined
+    ? cosignKmsSigner(signing.kmsKeyArn, env)
+    : notationSigner(signing.signingProfileArn!, env);
+}
+
+/**
+ * Signs with cosign and a KMS key. Signatures are stored under the cosign tag of the image digest
+ * and aren't uploaded to a public transparency log.
+ */
+function cosignKmsSigner(kmsKeyArn: string, env: NodeJS.ProcessEnv): ImageSigner {
+  const key = `awskms:///${kmsKeyArn}`;
+  return {
+    sign: async (imageRef) => {
+      // Signing again would add another signature for every update.
+      const verifyArgs = ["verify", "--key", key, "--insecure-ignore-tlog=true", imageRef];
+      if (!(await succeeds("cosign", verifyArgs, env))) {
+        await run("cosign", ["sign", "--yes", "--key", key, "--tlog-upload=false", imageRef], env);
+      }
+      const [repository, digest] = imageRef.split("@");
+      return `${repository}:${digest.replace(":", "-")}.sig`;
+    },
+  };
+}
+
+/**
+ * Signs with Notation and the AWS Signer plugin. The signature is stored as a referrer of the
+ * image.
+ */
+function notationSigner(signingProfileArn: string, env: NodeJS.ProcessEnv): ImageSigner {
+  const signatureDigests = async (imageRef: string): Promise<string[]> => {
+    const inspected = JSON.parse(
+      await run("notation", ["inspect", "--output", "json", imageRef], env),
+    );
+    return (inspected.signatures ?? []).map((s: { digest: string }) => s.digest);
+  };
+  return {
+    sign: async (imageRef) => {
+      const repository = imageRef.split("@")[0];
+      // Images are only signed once, so that updates don't pile up signatures.
+      let digests = await signatureDigests(imageRef);
+      if (digests.length === 0) {
+        await run(
+          "notation",
+          [
+            "sign",
+            "--plugin",
+            "com.amazonaws.signer.notation.plugin",
+            "--id",
+            signingProfileArn,
+            imageRef,
+          ],
+          env,
+        );
+        digests = await signatureDigests(imageRef);
+      }
+      return `${repository}@${digests[0]}`;
+    },
+  };
+}
+
+/**
+ * Runs the callback with a Docker config holding the registry credentials, which cosign and
+ * notation both read. This keeps the token off the command lines.
+ */
+async function withDockerConfig<T>(
+  credentials: DockerCredentials,
+  callback: (env: NodeJS.ProcessEnv) => Promise<T>,
+): Promise<T> {
+  const dir = fs.mkdtempSync(path.join(os.tmpdir(), "awsx-docker-config-"));
+  try {
+    const auth = Buffer.from(`${credentials.username}:${credentials.password}`).toString("base64");
+    const host = credentials.address.replace(/^https?:\/\//, "");
+    const config = { auths: { [host]: { auth } } };
+    fs.writeFileSync(path.join(dir, "config.json"), JSON.stringify(config), { mode: 0o600 });
+    return await callback({ ...process.env, DOCKER_CONFIG: dir });
+  } finally {
+    fs.rmSync(dir, { recursive: true, force: true });
+  }
+}
+
+class MissingCliError extends Error {}
+
+async function run(command: string, args: string[], env: NodeJS.ProcessEnv): Promise<string> {
+  try {
+    const { stdout } = await execFile(command, args, { env, maxBuffer: 64 * 1024 * 1024 });
+    return stdout;
+  } catch (e) {
+    if ((e as NodeJS.ErrnoException).code === "ENOENT") {
+      throw new MissingCliError(
+        `The ${command} CLI must be installed to sign images`,
+      );
+    }
+    throw e;
+  }
+}
+
+// Runs a verification command, which fails if the image has no matching signature yet.
+async function succeeds(command: string, args: string[], env: NodeJS.ProcessEnv) {
+  try {
+    await run(command, args, env);
+    return true;
+  } catch (e) {
+    if (e instanceof MissingCliError) {
+      throw e;
+    }
+    return false;
+  }
+}
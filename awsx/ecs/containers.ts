@@ -17,6 +17,7 @@ import * as pulumi from "@pulumi/pulumi";
 import { LogGroupId } from "../cloudwatch/logGroup";
 import * as schema from "../schema-types";
 import * as utils from "../utils";
+import { mergeSecrets } from "./secrets";
 
 /** @internal */
 export function normalizeTaskDefinitionContainers(
@@ -79,9 +80,11 @@ function computeContainerDefinition(
   return pulumi
     .all([container, resolvedMappings, logGroupId])
     .apply(([container, portMappings, logGroupId]) => {
+      const { secretSources, ...rest } = container;
       const containerDefinition = {
-        ...container,
+        ...rest,
         portMappings,
+        secrets: mergeSecrets(containerName, rest.secrets, secretSources),
         name: containerName,
       };
       if (containerDefinition.logConfiguration === undefined && logGroupId !== undefined) {
@@ -17,6 +17,13 @@ import * as pulumi from "@pulumi/pulumi";
 import * as schema from "../schema-types";
 import * as utils from "../utils";
 import { EC2TaskDefinition } from "./ec2TaskDefinition";
+import { withEfsClientSecurityGroups } from "./efsVolumes";
+import { configureExec, enableExecuteCommand } from "./exec";
+import {
+  allowLoadBalancerTraffic,
+  hasLoadBalancers,
+  withLoadBalancerIngressSecurityGroup,
+} from "./loadBalancerSecurityGroups";
 
 /**
  * Create an ECS Service resource for EC2 with the given unique name, arguments, and options.
@@ -53,6 +60,17 @@ export class EC2Service extends schema.EC2Service {
       throw new Error("Either `taskDefinition` or `taskDefinitionArgs` must be provided.");
     }
 
+    const exec = configureExec(
+      name,
+      args.exec,
+      args.cluster,
+      taskDefinition?.taskRole,
+      args.region,
+      { parent: this },
+    );
+    this.execLogGroup = exec.logGroup;
+    this.execBucket = exec.bucket;
+
     if (args.capacityProviderStrategies && args.useClusterDefaultCapacityProviderStrategy) {
       throw new Error(
         "Only one of `capacityProviderStrategies` or `useClusterDefaultCapacityProviderStrategy` can be provided.",
@@ -66,13 +84,34 @@ export class EC2Service extends schema.EC2Service {
         ? undefined
         : "EC2";
 
+    const loadBalancers = args.loadBalancers ?? taskDefinition?.loadBalancers;
+    const networkConfiguration =
+      args.networkConfiguration &&
+      withEfsClientSecurityGroups(args.networkConfiguration, args.taskDefinitionArgs?.efsVolumes);
+    this.loadBalancerIngressSecurityGroup = allowLoadBalancerTraffic(
+      name,
+      args.loadBalancerSecurityGroups ?? [],
+      hasLoadBalancers(args.loadBalancers, args.taskDefinitionArgs),
+      loadBalancers,
+      networkConfiguration,
+      args.region,
+      { parent: this, dependsOn: opts.dependsOn },
+    );
+
     this.service = new aws.ecs.Service(
       name,
       {
         ...args,
+        networkConfiguration:
+          networkConfiguration &&
+          withLoadBalancerIngressSecurityGroup(
+            networkConfiguration,
+            this.loadBalancerIngressSecurityGroup,
+          ),
         cluster: aws.ecs.Cluster.isInstance(args.cluster) ? args.cluster.arn : args.cluster,
         launchType,
-        loadBalancers: args.loadBalancers ?? taskDefinition?.loadBalancers,
+        enableExecuteCommand: enableExecuteCommand(args.enableExecuteCommand, args.exec),
+        loadBalancers,
         waitForSteadyState: utils
           .ifUndefined(args.continueBeforeSteadyState, false)
           .apply((x) => !x),
@@ -23,6 +23,9 @@ import {
   computeContainerDefinitions,
   computeLoadBalancers,
 } from "./containers";
+import { applyEfsMountPoints, computeVolumes, efsGrants } from "./efsVolumes";
+import { secretGrants } from "./secrets";
+import { applySidecars, sidecarGrants } from "./sidecars";
 
 /**
  * Create a TaskDefinition resource with the given unique name, arguments, and options.
@@ -55,11 +58,17 @@ export class EC2TaskDefinition extends schema.EC2TaskDefinition {
       },
     );
 
-    const containers = normalizeTaskDefinitionContainers(args);
-
     const { logGroup, logGroupId } = defaultLogGroup(name, args.logGroup, {}, { parent: this });
     this.logGroup = logGroup;
 
+    const containers = applySidecars(
+      applyEfsMountPoints(normalizeTaskDefinitionContainers(args), args.efsVolumes),
+      args.sidecars,
+      logGroupId,
+      "EC2",
+      args.networkMode,
+    );
+
     const taskRole = role.defaultRoleWithPolicies(
       `${name}-task`,
       args.taskRole,
@@ -81,6 +90,32 @@ export class EC2TaskDefinition extends schema.EC2TaskDefinition {
     this.taskRole = taskRole.role;
     this.executionRole = executionRole.role;
 
+    const grants = sidecarGrants(args.sidecars, logGroupId);
+    role.grantRolePolicies(
+      `${name}-task-sidecars`,
+      taskRole.role,
+      { managedPolicies: grants.taskRoleManagedPolicies, statements: grants.taskRoleStatements },
+      { parent: this },
+    );
+    role.grantRolePolicies(
+      `${name}-execution-sidecars`,
+      executionRole.role,
+      { statements: grants.executionRoleStatements },
+      { parent: this },
+    );
+    role.grantRolePolicies(
+      `${name}-execution-secrets`,
+      executionRole.role,
+      { statements: secretGrants(this, args) },
+      { parent: this },
+    );
+    role.grantRolePolicies(
+      `${name}-task-efs`,
+      taskRole.role,
+      { statements: efsGrants(args.efsVolumes) },
+      { parent: this },
+    );
+
     const containerDefinitions = computeContainerDefinitions(this, containers, logGroupId);
 
     this.loadBalancers = computeLoadBalancers(containers);
@@ -106,7 +141,8 @@ function buildTaskDefinitionArgs(
   taskRoleArn?: pulumi.Input<string>,
   executionRoleArn?: pulumi.Input<string>,
 ): aws.ecs.TaskDefinitionArgs {
-  const mutableArgs = { ...args };
+  const { efsVolumes, ...mutableArgs } = args;
+  mutableArgs.volumes = computeVolumes(args.volumes, efsVolumes);
 
   const containerString = containerDefinitions.apply((d) => JSON.stringify(d));
   const defaultFamily = containerString.apply(
@@ -0,0 +1,69 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+import * as pulumi from "@pulumi/pulumi";
+
+import * as schema from "../schema-types";
+import { applyEfsMountPoints } from "./efsVolumes";
+
+function promiseOf<T>(output: pulumi.Output<T>): Promise<T> {
+  return new Promise((resolve) => output.apply(resolve));
+}
+
+const fileSystem = {} as schema.FileSystem;
+
+describe("EFS mount points", () => {
+  it("mounts volumes with a container path into the containers", async () => {
+    const containers = applyEfsMountPoints(
+      pulumi.output({
+        app: { name: "app", image: "app-image" },
+        worker: { name: "worker", image: "worker-image" },
+      }),
+      {
+        data: { fileSystem, containerPath: "/data", containerNames: ["app"] },
+        shared: { fileSystem, containerPath: "/shared", readOnly: true },
+      },
+    );
+    const resolved = await promiseOf(containers);
+
+    expect(await promiseOf(pulumi.output(resolved.app.mountPoints))).toEqual([
+      { sourceVolume: "data", containerPath: "/data" },
+      { sourceVolume: "shared", containerPath: "/shared", readOnly: true },
+    ]);
+    expect(await promiseOf(pulumi.output(resolved.worker.mountPoints))).toEqual([
+      { sourceVolume: "shared", containerPath: "/shared", readOnly: true },
+    ]);
+  });
+
+  it("makes existing mount points of read-only volumes read-only", async () => {
+    const containers = applyEfsMountPoints(
+      pulumi.output({
+        app: {
+          name: "app",
+          image: "app-image",
+          mountPoints: [
+            { sourceVolume: "shared", containerPath: "/shared", readOnly: false },
+            { sourceVolume: "scratch", containerPath: "/tmp" },
+          ],
+        },
+      }),
+      { shared: { fileSystem, readOnly: true } },
+    );
+    const resolved = await promiseOf(containers);
+
+    expect(await promiseOf(pulumi.output(resolved.app.mountPoints))).toEqual([
+      { sourceVolume: "shared", containerPath: "/shared", readOnly: true },
+      { sourceVolume: "scratch", containerPath: "/tmp" },
+    ]);
+  });
+});
@@ -0,0 +1,165 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import * as aws from "@pulumi/aws";
+import * as pulumi from "@pulumi/pulumi";
+import * as schema from "../schema-types";
+
+type EfsVolumes = Record<string, schema.TaskDefinitionEfsVolumeInputs> | undefined;
+
+/**
+ * Appends a volume for each EFS volume to the task definition's [volumes].
+ * @internal
+ */
+export function computeVolumes(
+  volumes: pulumi.Input<pulumi.Input<aws.types.input.ecs.TaskDefinitionVolume>[]> | undefined,
+  efsVolumes: EfsVolumes,
+): pulumi.Input<pulumi.Input<aws.types.input.ecs.TaskDefinitionVolume>[]> | undefined {
+  if (efsVolumes === undefined) {
+    return volumes;
+  }
+  const efs = Object.entries(efsVolumes).map(
+    ([name, volume]): aws.types.input.ecs.TaskDefinitionVolume => {
+      if (volume.accessPointId !== undefined && volume.rootDirectory !== undefined) {
+        throw new Error(
+          `Only one of [accessPointId] or [rootDirectory] can be specified for EFS volume "${name}"`,
+        );
+      }
+      const iam = volume.iamAuthorization ?? true;
+      return {
+        name,
+        efsVolumeConfiguration: {
+          fileSystemId: pulumi.output(volume.fileSystem.fileSystem).apply((fs) => fs.id),
+          rootDirectory: volume.rootDirectory,
+          // IAM authorization and access points both require encryption in transit.
+          transitEncryption: "ENABLED",
+          authorizationConfig: {
+            accessPointId: volume.accessPointId,
+            iam: iam ? "ENABLED" : "DISABLED",
+          },
+        },
+      };
+    },
+  );
+  return pulumi
+    .all([pulumi.output(volumes ?? []), pulumi.output(efs)])
+    .apply(([existing, added]) => [...existing, ...added]);
+}
+
+type ContainerDefinitions = Record<string, schema.TaskDefinitionContainerDefinitionInputs>;
+
+/**
+ * Mounts the EFS volumes into the containers. Volumes with a [containerPath] get a mount point in
+ * each of their containers, and every mount point of a read-only volume is made read-only.
+ * @internal
+ */
+export function applyEfsMountPoints(
+  containers: pulumi.Output<ContainerDefinitions>,
+  efsVolumes: EfsVolumes,
+): pulumi.Output<ContainerDefinitions> {
+  if (efsVolumes === undefined || Object.keys(efsVolumes).length === 0) {
+    return containers;
+  }
+  return containers.apply((containers) => {
+    const result: ContainerDefinitions = { ...containers };
+    for (const [volumeName, volume] of Object.entries(efsVolumes)) {
+      if (volume.containerPath === undefined) {
+        if (volume.containerNames !== undefined) {
+          throw new Error(
+            `[containerNames] requires [containerPath] for EFS volume "${volumeName}"`,
+          );
+        }
+        continue;
+      }
+      for (const containerName of volume.containerNames ?? Object.keys(containers)) {
+        const container = result[containerName];
+        if (container === undefined) {
+          throw new Error(
+            `EFS volume "${volumeName}" refers to unknown container "${containerName}" ` +
+              "in [containerNames].",
+          );
+        }
+        result[containerName] = {
+          ...container,
+          mountPoints: pulumi.output(container.mountPoints ?? []).apply((mountPoints) => [
+            ...mountPoints,
+            { sourceVolume: volumeName, containerPath: volume.containerPath },
+          ]),
+        };
+      }
+    }
+    for (const [containerName, container] of Object.entries(result)) {
+      if (container.mountPoints !== undefined) {
+        result[containerName] = {
+          ...container,
+          mountPoints: pulumi
+            .output(container.mountPoints)
+            .apply((mountPoints) =>
+              mountPoints.map((mountPoint) =>
+                mountPoint.sourceVolume !== undefined &&
+                efsVolumes[mountPoint.sourceVolume]?.readOnly
+                  ? { ...mountPoint, readOnly: true }
+                  : mountPoint,
+              ),
+            ),
+        };
+      }
+    }
+    return result;
+  });
+}
+
+/**
+ * Collects the statements the task role needs to mount the EFS volumes.
+ * @internal
+ */
+export function efsGrants(efsVolumes: EfsVolumes): aws.types.input.iam.PolicyStatement[] {
+  return Object.values(efsVolumes ?? {})
+    .filter((volume) => volume.iamAuthorization ?? true)
+    .map((volume) => ({
+      Effect: "Allow",
+      Action: volume.readOnly
+        ? ["elasticfilesystem:ClientMount"]
+        : ["elasticfilesystem:ClientMount", "elasticfilesystem:ClientWrite"],
+      Resource: pulumi.output(volume.fileSystem.fileSystem).apply((fs) => fs.arn),
+    }));
+}
+
+/**
+ * Adds the client security groups of the EFS volumes to the service's network configuration so
+ * its tasks can reach the file systems over NFS.
+ * @internal
+ */
+export function withEfsClientSecurityGroups(
+  networkConfiguration: pulumi.Input<aws.types.input.ecs.ServiceNetworkConfiguration>,
+  efsVolumes: EfsVolumes,
+): pulumi.Input<aws.types.input.ecs.ServiceNetworkConfiguration> {
+  if (efsVolumes === undefined || Object.keys(efsVolumes).length === 0) {
+    return networkConfiguration;
+  }
+  const clientSecurityGroupIds = Object.values(efsVolumes).map((volume) =>
+    pulumi.output(volume.fileSystem.clientSecurityGroup).apply((sg) => sg?.id),
+  );
+  return pulumi
+    .all([pulumi.output(networkConfiguration), pulumi.all(clientSecurityGroupIds)])
+    .apply(([config, ids]) => {
+      const securityGroups = [...(config.securityGroups ?? [])];
+      for (const id of ids) {
+        if (id !== undefined && !securityGroups.includes(id)) {
+          securityGroups.push(id);
+        }
+      }
+      return { ...config, securityGroups };
+    });
+}
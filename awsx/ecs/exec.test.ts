@@ -0,0 +1,136 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+import * as aws from "@pulumi/aws";
+import * as pulumi from "@pulumi/pulumi";
+
+import { mockResources } from "../tests/mocks";
+import { enableExecuteCommand } from "./exec";
+import { FargateService } from "./fargateService";
+
+function promiseOf<T>(output: pulumi.Output<T>): Promise<T> {
+  return new Promise((resolve) => output.apply(resolve));
+}
+
+describe("enableExecuteCommand", () => {
+  it("passes the value through without exec", () => {
+    expect(enableExecuteCommand(undefined, undefined)).toBeUndefined();
+    expect(enableExecuteCommand(false, undefined)).toBe(false);
+  });
+
+  it("enables execute command with exec", () => {
+    expect(enableExecuteCommand(undefined, {})).toBe(true);
+  });
+
+  it("rejects disabling execute command with exec", () => {
+    expect(() => enableExecuteCommand(false, {})).toThrow(
+      "[enableExecuteCommand] can't be false when [exec] is specified",
+    );
+  });
+});
+
+describe("FargateService exec", () => {
+  const { registeredResource } = mockResources({
+    state: (args) => ({ name: args.name }),
+  });
+
+  it("grants the task role the ECS Exec permissions", async () => {
+    const service = new FargateService("exec", {
+      cluster: "cluster-arn",
+      networkConfiguration: { subnets: ["subnet-1"] },
+      taskDefinitionArgs: { container: { name: "app", image: "app-image" } },
+      exec: { logGroup: { enable: true }, s3Bucket: {}, s3KeyPrefix: "sessions/" },
+    });
+    expect(service.execLogGroup).toBeDefined();
+    expect(service.execBucket).toBeDefined();
+
+    const ecsService = await registeredResource("aws:ecs/service:Service", "exec");
+    expect(ecsService.inputs.enableExecuteCommand).toBe(true);
+
+    const rolePolicy = await registeredResource("aws:iam/rolePolicy:RolePolicy", "exec-task-exec");
+    const policy = JSON.parse(rolePolicy.inputs.policy);
+    expect(policy.Statement[0].Action).toContain("ssmmessages:CreateControlChannel");
+    expect(policy.Statement).toContainEqual({
+      Effect: "Allow",
+      Action: ["s3:PutObject"],
+      Resource: "arn:exec-exec/sessions/*",
+    });
+    expect(policy.Statement).toContainEqual({
+      Effect: "Allow",
+      Action: ["logs:CreateLogStream", "logs:DescribeLogStreams", "logs:PutLogEvents"],
+      Resource: "arn:aws:logs:us-west-2:123456789012:log-group:exec-exec:*",
+    });
+  });
+
+  it("does not create log destinations by default", async () => {
+    const service = new FargateService("exec-default", {
+      cluster: "cluster-arn",
+      networkConfiguration: { subnets: ["subnet-1"] },
+      taskDefinitionArgs: { container: { name: "app", image: "app-image" } },
+      exec: {},
+    });
+    expect(service.execLogGroup).toBeUndefined();
+    expect(service.execBucket).toBeUndefined();
+    await promiseOf(service.service.id);
+  });
+
+  it("grants access to the session key of a cluster logging to the exec destinations", async () => {
+    const logGroupName = "exec-sessions";
+    const cluster = new aws.ecs.Cluster("exec-cluster", {
+      configuration: {
+        executeCommandConfiguration: {
+          kmsKeyId: "key-123",
+          logging: "OVERRIDE",
+          logConfiguration: { cloudWatchLogGroupName: logGroupName },
+        },
+      },
+    });
+    new FargateService("exec-kms", {
+      cluster: cluster as any,
+      networkConfiguration: { subnets: ["subnet-1"] },
+      taskDefinitionArgs: { container: { name: "app", image: "app-image" } },
+      exec: { logGroup: { enable: true, existing: { name: logGroupName } }, kmsKeyId: "key-123" },
+    });
+
+    const rolePolicy = await registeredResource(
+      "aws:iam/rolePolicy:RolePolicy",
+      "exec-kms-task-exec",
+    );
+    const policy = JSON.parse(rolePolicy.inputs.policy);
+    expect(policy.Statement[0].Resource).toBe("*");
+    expect(policy.Statement).toContainEqual({
+      Effect: "Allow",
+      Action: ["kms:Decrypt"],
+      Resource: "arn:aws:kms:us-west-2:123456789012:key/key-123",
+    });
+  });
+
+  it("doesn't check the cluster against destinations created by the service", async () => {
+    const cluster = new aws.ecs.Cluster("exec-managed-cluster", {
+      configuration: { executeCommandConfiguration: { logging: "DEFAULT" } },
+    });
+    new FargateService("exec-managed", {
+      cluster: cluster as any,
+      networkConfiguration: { subnets: ["subnet-1"] },
+      taskDefinitionArgs: { container: { name: "app", image: "app-image" } },
+      exec: { logGroup: { enable: true }, s3Bucket: {} },
+    });
+
+    const rolePolicy = await registeredResource(
+      "aws:iam/rolePolicy:RolePolicy",
+      "exec-managed-task-exec",
+    );
+    const policy = JSON.parse(rolePolicy.inputs.policy);
+    expect(policy.Statement[0].Resource).toBe("*");
+  });
+});
@@ -0,0 +1,222 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import * as aws from "@pulumi/aws";
+import * as pulumi from "@pulumi/pulumi";
+import { optionalLogGroup } from "../cloudwatch/logGroup";
+import * as role from "../role";
+import { defaultBucket } from "../s3/bucket";
+import * as schema from "../schema-types";
+import { getRegionFromOpts } from "../utils";
+
+/**
+ * Resolves the `enableExecuteCommand` of a service with the [exec] option.
+ * @internal
+ */
+export function enableExecuteCommand(
+  enableExecuteCommand: pulumi.Input<boolean> | undefined,
+  exec: schema.ServiceExecInputs | undefined,
+): pulumi.Input<boolean> | undefined {
+  if (exec === undefined) {
+    return enableExecuteCommand;
+  }
+  if (enableExecuteCommand === false) {
+    throw new Error("[enableExecuteCommand] can't be false when [exec] is specified");
+  }
+  return true;
+}
+
+/**
+ * Creates the session log destinations of ECS Exec and grants the task role the permissions
+ * the ECS Exec agent needs to open sessions, decrypt them and write their logs.
+ *
+ * ECS only writes session logs when the cluster's `executeCommandConfiguration` points at them. The
+ * service doesn't own the cluster, so when the cluster is passed as a resource its configuration
+ * is checked during deployment against the existing destinations. Destinations created by the
+ * service can't be checked, as the cluster is configured before them, so a warning is logged.
+ * @internal
+ */
+export function configureExec(
+  name: string,
+  exec: schema.ServiceExecInputs | undefined,
+  cluster: pulumi.Input<string> | aws.ecs.Cluster | undefined,
+  taskRole: aws.iam.Role | undefined,
+  region: pulumi.Input<string> | undefined,
+  opts: pulumi.ComponentResourceOptions,
+): {
+  logGroup?: aws.cloudwatch.LogGroup;
+  bucket?: aws.s3.Bucket;
+} {
+  if (exec === undefined) {
+    return {};
+  }
+
+  const { logGroup, logGroupId } = optionalLogGroup(
+    `${name}-exec`,
+    exec.logGroup,
+    { region },
+    opts,
+  );
+  const { bucket, bucketId } =
+    exec.s3Bucket === undefined
+      ? { bucket: undefined, bucketId: undefined }
+      : defaultBucket(`${name}-exec`, exec.s3Bucket, { region }, opts);
+
+  const clusterResource = aws.ecs.Cluster.isInstance(cluster) ? cluster : undefined;
+  if (clusterResource === undefined && (logGroupId !== undefined || bucketId !== undefined)) {
+    pulumi.log.warn(
+      "ECS Exec session logs are only written if the cluster's [executeCommandConfiguration] " +
+        "logs to the [execLogGroup] and [execBucket] of the service, which can't be checked " +
+        "unless [cluster] is passed as a resource.",
+      opts.parent,
+    );
+  } else if (clusterResource !== undefined && (logGroup !== undefined || bucket !== undefined)) {
+    pulumi.log.warn(
+      "ECS Exec session logs are only written if the cluster's [executeCommandConfiguration] " +
+        "logs to the [execLogGroup] and [execBucket] created by the service, which can't be " +
+        "checked as the cluster is configured before them. Pass existing destinations in " +
+        "[exec] to have them checked.",
+      opts.parent,
+    );
+  }
+  // The permissions are gated on the check, so that a misconfigured cluster fails the deployment
+  // instead of sessions silently going unlogged or failing to start.
+  const checkedCluster = pulumi
+    .all([
+      clusterResource?.configuration,
+      logGroup === undefined ? logGroupId?.apply((id) => id.logGroupName) : undefined,
+      bucket === undefined ? bucketId?.name : undefined,
+      exec.s3KeyPrefix,
+      exec.kmsKeyId,
+    ])
+    .apply(([configuration, logGroupName, bucketName, s3KeyPrefix, kmsKeyId]) => {
+      if (clusterResource !== undefined) {
+        checkClusterExecConfiguration(name, configuration?.executeCommandConfiguration, {
+          logGroupName,
+          bucketName,
+          s3KeyPrefix,
+          kmsKeyId,
+        });
+      }
+      return "*";
+    });
+
+  const statements: aws.types.input.iam.PolicyStatement[] = [
+    {
+      Effect: "Allow",
+      Action: [
+        "ssmmessages:CreateControlChannel",
+        "ssmmessages:CreateDataChannel",
+        "ssmmessages:OpenControlChannel",
+        "ssmmessages:OpenDataChannel",
+      ],
+      Resource: checkedCluster,
+    },
+  ];
+  if (exec.kmsKeyId !== undefined) {
+    // The ECS Exec agent in the task decrypts sessions encrypted with a KMS key.
+    statements.push({
+      Effect: "Allow",
+      Action: ["kms:Decrypt"],
+      Resource: kmsKeyArn(exec.kmsKeyId, region, opts),
+    });
+  }
+  if (logGroupId !== undefined) {
+    statements.push(
+      {
+        Effect: "Allow",
+        Action: ["logs:DescribeLogGroups"],
+        Resource: "*",
+      },
+      {
+        Effect: "Allow",
+        Action: ["logs:CreateLogStream", "logs:DescribeLogStreams", "logs:PutLogEvents"],
+        Resource: logGroupId.apply((id) => `${id.arn}:*`),
+      },
+    );
+  }
+  if (bucketId !== undefined) {
+    statements.push(
+      {
+        Effect: "Allow",
+        Action: ["s3:GetEncryptionConfiguration"],
+        Resource: bucketId.arn,
+      },
+      {
+        Effect: "Allow",
+        Action: ["s3:PutObject"],
+        Resource: pulumi.interpolate`${bucketId.arn}/${exec.s3KeyPrefix ?? ""}*`,
+      },
+    );
+  }
+  role.grantRolePolicies(`${name}-task-exec`, taskRole, { statements }, opts);
+
+  return { logGroup, bucket };
+}
+
+/**
+ * Ensures that a cluster logs ECS Exec sessions to the existing destinations given to the service,
+ * and that the service knows the key the sessions are encrypted with.
+ */
+function checkClusterExecConfiguration(
+  name: string,
+  config: aws.types.output.ecs.ClusterConfigurationExecuteCommandConfiguration | undefined,
+  exec: { logGroupName?: string; bucketName?: string; s3KeyPrefix?: string; kmsKeyId?: string },
+) {
+  if (config?.kmsKeyId !== undefined && exec.kmsKeyId === undefined) {
+    throw new Error(
+      `The cluster of service "${name}" encrypts ECS Exec sessions with a KMS key, so ` +
+        "[exec] [kmsKeyId] must be set for the task role to be granted access to it.",
+    );
+  }
+  if (exec.logGroupName === undefined && exec.bucketName === undefined) {
+    return;
+  }
+  const logging = config?.logConfiguration;
+  const mismatches: string[] = [];
+  if (exec.logGroupName !== undefined && logging?.cloudWatchLogGroupName !== exec.logGroupName) {
+    mismatches.push(`[cloudWatchLogGroupName] "${exec.logGroupName}"`);
+  }
+  if (exec.bucketName !== undefined && logging?.s3BucketName !== exec.bucketName) {
+    mismatches.push(`[s3BucketName] "${exec.bucketName}"`);
+  }
+  if (exec.bucketName !== undefined && (logging?.s3KeyPrefix ?? "") !== (exec.s3KeyPrefix ?? "")) {
+    mismatches.push(`[s3KeyPrefix] "${exec.s3KeyPrefix ?? ""}"`);
+  }
+  if (config?.logging !== "OVERRIDE" || mismatches.length > 0) {
+    throw new Error(
+      `The cluster of service "${name}" doesn't write ECS Exec session logs to the destinations ` +
+        'in [exec]. Its [executeCommandConfiguration] must set [logging] to "OVERRIDE"' +
+        (mismatches.length > 0 ? ` and [logConfiguration] ${mismatches.join(", ")}.` : "."),
+    );
+  }
+}
+
+// Resolves a KMS key ID into its ARN, as IAM policies require ARNs.
+function kmsKeyArn(
+  keyId: pulumi.Input<string>,
+  region: pulumi.Input<string> | undefined,
+  opts: pulumi.ComponentResourceOptions,
+): pulumi.Output<string> {
+  return pulumi
+    .all([
+      keyId,
+      aws.getPartitionOutput({}, opts).partition,
+      region ?? getRegionFromOpts(opts),
+      aws.getCallerIdentity({}, opts),
+    ])
+    .apply(([id, partition, region, identity]) =>
+      id.startsWith("arn:") ? id : `arn:${partition}:kms:${region}:${identity.accountId}:key/${id}`,
+    );
+}
@@ -12,7 +12,16 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-import { calculateFargateMemoryAndCPU, maxMemGB, maxVCPU } from "./fargateMemoryAndCpu";
+import * as fs from "fs";
+import * as path from "path";
+import {
+  calculateFargateMemoryAndCPU,
+  fargateTaskSizeMemoryAndCpu,
+  maxMemGB,
+  maxVCPU,
+  supportedTaskSizes,
+  validateFargateMemoryAndCpu,
+} from "./fargateMemoryAndCpu";
 
 describe("max vcpu and memory", () => {
   it("max cpu", async () => {
@@ -108,3 +117,64 @@ describe("max vcpu and memory", () => {
     );
   });
 });
+
+describe("explicit task sizes", () => {
+  it("converts task sizes into cpu units and MiB", () => {
+    expect(fargateTaskSizeMemoryAndCpu("0.25vCPU-512MiB")).toEqual({ cpu: "256", memory: "512" });
+    expect(fargateTaskSizeMemoryAndCpu("16vCPU-120GiB")).toEqual({
+      cpu: "16384",
+      memory: "122880",
+    });
+  });
+
+  it("accepts supported cpu and memory pairs", () => {
+    expect(() => validateFargateMemoryAndCpu("1024", "2048")).not.toThrow();
+    expect(() => validateFargateMemoryAndCpu("1 vCPU", "2GB")).not.toThrow();
+    expect(() => validateFargateMemoryAndCpu("8192", "20480")).not.toThrow();
+  });
+
+  it("leaves values it cannot parse to AWS", () => {
+    expect(() => validateFargateMemoryAndCpu("lots", "2048")).not.toThrow();
+  });
+
+  it("lists the valid memory values for an unsupported pair", () => {
+    expect(() => validateFargateMemoryAndCpu("8192", "21504")).toThrowError(
+      "Fargate does not support 21504 memory for a task cpu of 8192. " +
+        "Valid memory values (in MiB) for 8192 cpu units are: 16384, 20480, 24576, 28672, 32768, 36864, 40960, 45056, 49152, " +
+        "53248, 57344, 61440.",
+    );
+  });
+
+  it("lists the valid cpu values for an unsupported cpu", () => {
+    expect(() => validateFargateMemoryAndCpu("300", "1024")).toThrowError(
+      "Fargate does not support a task cpu of 300. Valid cpu values are: 256, 512, 1024, 2048, " +
+        "4096, 8192, 16384.",
+    );
+  });
+
+  it("validates cpu when memory is not set", () => {
+    expect(() => validateFargateMemoryAndCpu("2048", undefined)).not.toThrow();
+    expect(() => validateFargateMemoryAndCpu("300", undefined)).toThrowError(
+      "Fargate does not support a task cpu of 300.",
+    );
+  });
+
+  it("validates memory when cpu is not set", () => {
+    expect(() => validateFargateMemoryAndCpu(undefined, "30 GB")).not.toThrow();
+    expect(() => validateFargateMemoryAndCpu(undefined, "1536")).toThrowError(
+      "Fargate does not support a task memory of 1536.",
+    );
+  });
+
+  it("matches the FargateTaskSize enum of the schema", () => {
+    const schemaPath = path.join(__dirname, "../../provider/cmd/pulumi-resource-awsx/schema.json");
+    const schema = JSON.parse(fs.readFileSync(schemaPath, "utf8"));
+    const enumSizes = schema.types["awsx:ecs:FargateTaskSize"].enum.map((e: { value: string }) =>
+      fargateTaskSizeMemoryAndCpu(e.value),
+    );
+    const tableSizes = supportedTaskSizes.flatMap((s) =>
+      s.memoryMiBs.map((memory) => ({ cpu: `${s.cpu}`, memory: `${memory}` })),
+    );
+    expect(enumSizes).toEqual(tableSizes);
+  });
+});
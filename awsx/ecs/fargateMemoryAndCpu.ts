@@ -14,8 +14,8 @@
 
 import * as aws from "@pulumi/aws";
 
-function* range(low: number, high: number) {
-  for (let i = low; i <= high; i++) {
+function* range(low: number, high: number, step = 1) {
+  for (let i = low; i <= high; i += step) {
     yield i;
   }
 }
@@ -124,3 +124,98 @@ function getRequestedVCPUandMemory(containers: FargateContainerMemoryAndCpu[]) {
 
   return { requestedVCPU, requestedGB };
 }
+
+/**
+ * The task sizes that can be explicitly requested, see
+ * https://docs.aws.amazon.com/AmazonECS/latest/developerguide/fargate-tasks-services.html#fargate-tasks-size
+ * A test checks that these match the FargateTaskSize enum of the schema.
+ * @internal
+ */
+export const supportedTaskSizes = [
+  { cpu: 256, memoryMiBs: [512, 1024, 2048] },
+  { cpu: 512, memoryMiBs: [...range(1024, 4096, 1024)] },
+  { cpu: 1024, memoryMiBs: [...range(2048, 8192, 1024)] },
+  { cpu: 2048, memoryMiBs: [...range(4096, 16384, 1024)] },
+  { cpu: 4096, memoryMiBs: [...range(8192, 30720, 1024)] },
+  { cpu: 8192, memoryMiBs: [...range(16384, 61440, 4096)] },
+  { cpu: 16384, memoryMiBs: [...range(32768, 122880, 8192)] },
+];
+
+/**
+ * Converts a task size like `0.25vCPU-512MiB` or `16vCPU-120GiB` into cpu units and MiB.
+ */
+export function fargateTaskSizeMemoryAndCpu(size: string) {
+  const match = /^(\d+(?:\.\d+)?)vCPU-(\d+)(MiB|GiB)$/.exec(size);
+  if (match === null) {
+    throw new Error(`Unknown Fargate task size "${size}".`);
+  }
+  const cpu = parseFloat(match[1]) * 1024;
+  const memory = parseInt(match[2], 10) * (match[3] === "GiB" ? 1024 : 1);
+  validateFargateMemoryAndCpu(`${cpu}`, `${memory}`);
+  return { memory: `${memory}`, cpu: `${cpu}` };
+}
+
+/**
+ * Ensures that explicitly requested cpu and memory values are supported by Fargate. When only one
+ * of them is set it must be part of some supported task size. Values in a format we don't
+ * understand are left to be validated by AWS.
+ */
+export function validateFargateMemoryAndCpu(cpu: string | undefined, memory: string | undefined) {
+  const cpuUnits = cpu === undefined ? undefined : parseCpuUnits(cpu);
+  const memoryMiB = memory === undefined ? undefined : parseMemoryMiB(memory);
+
+  if (cpuUnits === undefined) {
+    if (cpu === undefined && memoryMiB !== undefined) {
+      validateFargateMemory(memory!, memoryMiB);
+    }
+    return;
+  }
+
+  const taskSize = supportedTaskSizes.find((s) => s.cpu === cpuUnits);
+  if (taskSize === undefined) {
+    const validCpus = supportedTaskSizes.map((s) => s.cpu).join(", ");
+    throw new Error(
+      `Fargate does not support a task cpu of ${cpu}. Valid cpu values are: ${validCpus}.`,
+    );
+  }
+  if (memoryMiB !== undefined && !taskSize.memoryMiBs.includes(memoryMiB)) {
+    const validMemories = taskSize.memoryMiBs.join(", ");
+    throw new Error(
+      `Fargate does not support ${memory} memory for a task cpu of ${cpu}. ` +
+        `Valid memory values (in MiB) for ${cpuUnits} cpu units are: ${validMemories}.`,
+    );
+  }
+}
+
+function validateFargateMemory(memory: string, memoryMiB: number) {
+  if (!supportedTaskSizes.some((s) => s.memoryMiBs.includes(memoryMiB))) {
+    const validMemories = [...new Set(supportedTaskSizes.flatMap((s) => s.memoryMiBs))]
+      .sort((m1, m2) => m1 - m2)
+      .join(", ");
+    throw new Error(
+      `Fargate does not support a task memory of ${memory}. ` +
+        `Valid memory values (in MiB) are: ${validMemories}.`,
+    );
+  }
+}
+
+// Parses cpu values like `1024`, `1 vCPU` or `0.25 vcpu` into cpu units.
+function parseCpuUnits(cpu: string): number | undefined {
+  const match = /^(\d+(?:\.\d+)?)\s*(vcpu)?$/i.exec(cpu.trim());
+  if (match === null) {
+    return undefined;
+  }
+  const value = parseFloat(match[1]);
+  return match[2] === undefined ? value : value * 1024;
+}
+
+// Parses memory values like `512`, `1GB` or `1 GiB` into MiB.
+function parseMemoryMiB(memory: string): number | undefined {
+  const match = /^(\d+(?:\.\d+)?)\s*(mib|mb|gib|gb)?$/i.exec(memory.trim());
+  if (match === null) {
+    return undefined;
+  }
+  const value = parseFloat(match[1]);
+  const unit = match[2]?.toLowerCase();
+  return unit === "gb" || unit === "gib" ? value * 1024 : value;
+}
@@ -14,10 +14,17 @@
 
 import * as aws from "@pulumi/aws";
 import * as pulumi from "@pulumi/pulumi";
-import { getDefaultVpc } from "../ec2";
 import * as schema from "../schema-types";
 import * as utils from "../utils";
+import { withEfsClientSecurityGroups } from "./efsVolumes";
+import { configureExec, enableExecuteCommand } from "./exec";
 import { FargateTaskDefinition } from "./fargateTaskDefinition";
+import { createFrontends, serviceVpc, singleContainerName, ServiceVpc } from "./frontends";
+import {
+  allowLoadBalancerTraffic,
+  hasLoadBalancers,
+  withLoadBalancerIngressSecurityGroup,
+} from "./loadBalancerSecurityGroups";
 
 /**
  * Create an ECS Service resource for Fargate with the given unique name, arguments, and options.
@@ -57,6 +64,17 @@ export class FargateService extends schema.FargateService {
       throw new Error("Either `taskDefinition` or `taskDefinitionArgs` must be provided.");
     }
 
+    const exec = configureExec(
+      name,
+      args.exec,
+      args.cluster,
+      taskDefinition?.taskRole,
+      args.region,
+      { parent: this },
+    );
+    this.execLogGroup = exec.logGroup;
+    this.execBucket = exec.bucket;
+
     if (args.capacityProviderStrategies && args.useClusterDefaultCapacityProviderStrategy) {
       throw new Error(
         "Only one of `capacityProviderStrategies` or `useClusterDefaultCapacityProviderStrategy` can be provided.",
@@ -70,17 +88,83 @@ export class FargateService extends schema.FargateService {
         ? undefined
         : "FARGATE";
 
+    const {
+      vpc: vpcArg,
+      frontends: frontendArgs,
+      loadBalancerSecurityGroups: loadBalancerSecurityGroupArgs,
+      ...serviceArgs
+    } = args;
+    const vpc =
+      args.networkConfiguration === undefined || frontendArgs !== undefined
+        ? serviceVpc(vpcArg, this)
+        : undefined;
+
+    const loadBalancerSecurityGroups = [...(loadBalancerSecurityGroupArgs ?? [])];
+    let loadBalancers:
+      | pulumi.Input<pulumi.Input<aws.types.input.ecs.ServiceLoadBalancer>[]>
+      | undefined = args.loadBalancers ?? taskDefinition?.loadBalancers;
+    if (frontendArgs !== undefined) {
+      const frontends = createFrontends(
+        name,
+        frontendArgs,
+        vpc!,
+        singleContainerName(args.taskDefinitionArgs),
+        this,
+      );
+      this.frontends = frontends.loadBalancers;
+      this.frontendDnsNames = pulumi.all(
+        Object.fromEntries(
+          Object.entries(frontends.loadBalancers).map(([key, lb]) => [
+            key,
+            pulumi.output(lb.loadBalancer).dnsName,
+          ]),
+        ),
+      );
+      loadBalancers = pulumi
+        .all([loadBalancers ?? [], frontends.bindings])
+        .apply(([existing, bindings]) => [...existing, ...bindings]);
+      // The default network configuration already allows all inbound traffic.
+      if (args.networkConfiguration !== undefined) {
+        for (const lb of Object.values(frontends.loadBalancers)) {
+          loadBalancerSecurityGroups.push({
+            securityGroupId: pulumi.output(lb.defaultSecurityGroup!).id,
+            egress: false,
+          });
+        }
+      }
+    }
+
+    const networkConfiguration = withEfsClientSecurityGroups(
+      args.networkConfiguration ??
+        getDefaultNetworkConfiguration(name, this, vpc!, args.assignPublicIp),
+      args.taskDefinitionArgs?.efsVolumes,
+    );
+    // Load balancers are only detected with a given network configuration, as the default one
+    // already allows all inbound traffic.
+    this.loadBalancerIngressSecurityGroup = allowLoadBalancerTraffic(
+      name,
+      loadBalancerSecurityGroups,
+      args.networkConfiguration !== undefined &&
+        hasLoadBalancers(args.loadBalancers, args.taskDefinitionArgs),
+      loadBalancers,
+      networkConfiguration,
+      args.region,
+      { parent: this, dependsOn: opts.dependsOn },
+    );
+
     this.service = new aws.ecs.Service(
       name,
       {
         desiredCount: 1,
-        ...args,
-        networkConfiguration:
-          args.networkConfiguration ??
-          getDefaultNetworkConfiguration(name, this, args.assignPublicIp),
+        ...serviceArgs,
+        networkConfiguration: withLoadBalancerIngressSecurityGroup(
+          networkConfiguration,
+          this.loadBalancerIngressSecurityGroup,
+        ),
         cluster: aws.ecs.Cluster.isInstance(args.cluster) ? args.cluster.arn : args.cluster,
         launchType,
-        loadBalancers: args.loadBalancers ?? taskDefinition?.loadBalancers,
+        enableExecuteCommand: enableExecuteCommand(args.enableExecuteCommand, args.exec),
+        loadBalancers,
         waitForSteadyState: utils
           .ifUndefined(args.continueBeforeSteadyState, false)
           .apply((x) => !x),
@@ -96,13 +180,13 @@ export class FargateService extends schema.FargateService {
 function getDefaultNetworkConfiguration(
   name: string,
   parent: pulumi.Resource,
+  vpc: ServiceVpc,
   assignPublicIp?: pulumi.Input<boolean>,
 ): aws.types.input.ecs.ServiceNetworkConfiguration {
-  const defaultVpc = pulumi.output(getDefaultVpc({ parent }));
   const sg = new aws.ec2.SecurityGroup(
     `${name}-sg`,
     {
-      vpcId: defaultVpc.vpcId,
+      vpcId: vpc.vpcId,
       ingress: [
         {
           fromPort: 0,
@@ -129,13 +213,13 @@ function getDefaultNetworkConfiguration(
   assignPublicIp = assignPublicIp ?? false;
   return {
     subnets: pulumi
-      .all([assignPublicIp, defaultVpc.publicSubnetIds, defaultVpc.privateSubnetIds])
+      .all([assignPublicIp, vpc.publicSubnetIds, vpc.privateSubnetIds])
       .apply(([assignPublicIp, publicSubnetIds, privateSubnetIds]) => {
         if (!assignPublicIp && privateSubnetIds.length === 0) {
           throw new Error(
-            "The default VPC does not have any private subnets. " +
+            "The VPC does not have any private subnets. " +
               "Set `assignPublicIp` to `true`, provide `networkConfiguration`, or add " +
-              "private subnets to the default VPC.",
+              "private subnets to the VPC.",
           );
         }
         return assignPublicIp ? publicSubnetIds : privateSubnetIds;
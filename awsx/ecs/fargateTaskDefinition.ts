@@ -23,7 +23,14 @@ import {
   computeContainerDefinitions,
   computeLoadBalancers,
 } from "./containers";
-import { calculateFargateMemoryAndCPU } from "./fargateMemoryAndCpu";
+import { applyEfsMountPoints, computeVolumes, efsGrants } from "./efsVolumes";
+import { secretGrants } from "./secrets";
+import { applySidecars, sidecarGrants } from "./sidecars";
+import {
+  calculateFargateMemoryAndCPU,
+  fargateTaskSizeMemoryAndCpu,
+  validateFargateMemoryAndCpu,
+} from "./fargateMemoryAndCpu";
 
 /**
  * Create a TaskDefinition resource with the given unique name, arguments, and options.
@@ -56,11 +63,16 @@ export class FargateTaskDefinition extends schema.FargateTaskDefinition {
       },
     );
 
-    const containers = normalizeTaskDefinitionContainers(args);
-
     const { logGroup, logGroupId } = defaultLogGroup(name, args.logGroup, {}, { parent: this });
     this.logGroup = logGroup;
 
+    const containers = applySidecars(
+      applyEfsMountPoints(normalizeTaskDefinitionContainers(args), args.efsVolumes),
+      args.sidecars,
+      logGroupId,
+      "FARGATE",
+    );
+
     const taskRole = role.defaultRoleWithPolicies(
       `${name}-task`,
       args.taskRole,
@@ -82,6 +94,32 @@ export class FargateTaskDefinition extends schema.FargateTaskDefinition {
     this.taskRole = taskRole.role;
     this.executionRole = executionRole.role;
 
+    const grants = sidecarGrants(args.sidecars, logGroupId);
+    role.grantRolePolicies(
+      `${name}-task-sidecars`,
+      taskRole.role,
+      { managedPolicies: grants.taskRoleManagedPolicies, statements: grants.taskRoleStatements },
+      { parent: this },
+    );
+    role.grantRolePolicies(
+      `${name}-execution-sidecars`,
+      executionRole.role,
+      { statements: grants.executionRoleStatements },
+      { parent: this },
+    );
+    role.grantRolePolicies(
+      `${name}-execution-secrets`,
+      executionRole.role,
+      { statements: secretGrants(this, args) },
+      { parent: this },
+    );
+    role.grantRolePolicies(
+      `${name}-task-efs`,
+      taskRole.role,
+      { statements: efsGrants(args.efsVolumes) },
+      { parent: this },
+    );
+
     const containerDefinitions = computeContainerDefinitions(this, containers, logGroupId);
 
     this.loadBalancers = computeLoadBalancers(containers);
@@ -126,7 +164,34 @@ function buildTaskDefinitionArgs(
   taskRoleArn?: pulumi.Input<string>,
   executionRoleArn?: pulumi.Input<string>,
 ): aws.ecs.TaskDefinitionArgs {
-  const mutableArgs = { ...args };
+  const { size, architecture, efsVolumes, ...mutableArgs } = args;
+  mutableArgs.volumes = computeVolumes(args.volumes, efsVolumes);
+  if (size !== undefined) {
+    if (args.cpu !== undefined || args.memory !== undefined) {
+      throw new Error("Only one of [size] or [cpu] and [memory] can be specified");
+    }
+    const sizeMemoryAndCpu = fargateTaskSizeMemoryAndCpu(size);
+    mutableArgs.cpu = sizeMemoryAndCpu.cpu;
+    mutableArgs.memory = sizeMemoryAndCpu.memory;
+  } else {
+    // Values that are only known during deployment are left to AWS.
+    const cpu = typeof args.cpu === "string" ? args.cpu : undefined;
+    const memory = typeof args.memory === "string" ? args.memory : undefined;
+    if (
+      (args.cpu === undefined || cpu !== undefined) &&
+      (args.memory === undefined || memory !== undefined)
+    ) {
+      validateFargateMemoryAndCpu(cpu, memory);
+    }
+  }
+
+  if (architecture !== undefined) {
+    if (args.runtimePlatform !== undefined) {
+      throw new Error("Only one of [architecture] or [runtimePlatform] can be specified");
+    }
+    mutableArgs.runtimePlatform = { cpuArchitecture: architecture, operatingSystemFamily: "LINUX" };
+  }
+
   const requiredMemoryAndCPU = containerDefinitions
     .apply((defs) =>
       pulumi.all(
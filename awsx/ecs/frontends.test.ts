@@ -0,0 +1,92 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+import * as pulumi from "@pulumi/pulumi";
+
+import { mockResources } from "../tests/mocks";
+import { FargateService } from "./fargateService";
+
+function promiseOf<T>(output: pulumi.Output<T>): Promise<T> {
+  return new Promise((resolve) => output.apply(resolve));
+}
+
+describe("FargateService frontends", () => {
+  const { registeredResource } = mockResources({
+    state: (args) =>
+      args.type === "aws:lb/loadBalancer:LoadBalancer"
+        ? { dnsName: `${args.name}.elb.amazonaws.com` }
+        : undefined,
+  });
+
+  // Stands in for an awsx:ec2:Vpc, of which only the subnet IDs are used.
+  const vpc: any = {
+    vpcId: "vpc-123",
+    publicSubnetIds: ["public-a", "public-b"],
+    privateSubnetIds: ["private-a", "private-b"],
+    isolatedSubnetIds: [],
+  };
+
+  it("creates a public and an internal load balancer", async () => {
+    const service = new FargateService("app", {
+      cluster: "cluster-arn",
+      taskDefinition: "task-definition-arn",
+      vpc,
+      frontends: {
+        public: { containerName: "web", containerPort: 8080 },
+        internal: { subnetType: "Private", containerName: "web", containerPort: 8080, port: 8080 },
+      },
+    });
+
+    const publicLb = await registeredResource("aws:lb/loadBalancer:LoadBalancer", "app-public");
+    expect(publicLb.inputs).toMatchObject({ internal: false, subnets: ["public-a", "public-b"] });
+    const internalLb = await registeredResource(
+      "aws:lb/loadBalancer:LoadBalancer",
+      "app-internal",
+    );
+    expect(internalLb.inputs).toMatchObject({
+      internal: true,
+      subnets: ["private-a", "private-b"],
+    });
+
+    const internalListener = await registeredResource(
+      "aws:lb/listener:Listener",
+      "app-internal-0",
+    );
+    expect(internalListener.inputs).toMatchObject({ port: 8080, protocol: "HTTP" });
+
+    const ecsService = await registeredResource("aws:ecs/service:Service", "app");
+    expect(ecsService.inputs.loadBalancers).toEqual([
+      { targetGroupArn: "arn:app-public", containerName: "web", containerPort: 8080 },
+      { targetGroupArn: "arn:app-internal", containerName: "web", containerPort: 8080 },
+    ]);
+    expect(ecsService.inputs.networkConfiguration.subnets).toEqual(["private-a", "private-b"]);
+    expect(ecsService.inputs.vpc).toBeUndefined();
+
+    expect(await promiseOf(pulumi.output(service.frontendDnsNames!))).toEqual({
+      public: "app-public.elb.amazonaws.com",
+      internal: "app-internal.elb.amazonaws.com",
+    });
+  });
+
+  it("requires a container name without a single container", () => {
+    expect(
+      () =>
+        new FargateService("unnamed", {
+          cluster: "cluster-arn",
+          taskDefinition: "task-definition-arn",
+          vpc,
+          frontends: { public: { containerPort: 80 } },
+        }),
+    ).toThrow('[containerName] of frontend "public" must be specified');
+  });
+});
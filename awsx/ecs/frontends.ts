@@ -0,0 +1,133 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import * as aws from "@pulumi/aws";
+import * as pulumi from "@pulumi/pulumi";
+import { getDefaultVpc } from "../ec2";
+import { ApplicationLoadBalancer } from "../lb/applicationLoadBalancer";
+import * as schema from "../schema-types";
+
+/**
+ * Subnets of the VPC of a service.
+ * @internal
+ */
+export interface ServiceVpc {
+  vpcId: pulumi.Output<string>;
+  publicSubnetIds: pulumi.Output<string[]>;
+  privateSubnetIds: pulumi.Output<string[]>;
+  isolatedSubnetIds: pulumi.Output<string[]>;
+}
+
+/**
+ * Returns the subnets of the given VPC, or of the default VPC if none is given.
+ * @internal
+ */
+export function serviceVpc(vpc: schema.Vpc | undefined, parent: pulumi.Resource): ServiceVpc {
+  if (vpc !== undefined) {
+    return {
+      vpcId: pulumi.output(vpc.vpcId),
+      publicSubnetIds: pulumi.output(vpc.publicSubnetIds),
+      privateSubnetIds: pulumi.output(vpc.privateSubnetIds),
+      isolatedSubnetIds: pulumi.output(vpc.isolatedSubnetIds),
+    };
+  }
+  const defaultVpc = pulumi.output(getDefaultVpc({ parent }));
+  return {
+    vpcId: defaultVpc.vpcId,
+    publicSubnetIds: defaultVpc.publicSubnetIds,
+    privateSubnetIds: defaultVpc.privateSubnetIds,
+    isolatedSubnetIds: pulumi.output([]),
+  };
+}
+
+/**
+ * Returns the name of the container of the task definition if it has a single container.
+ * @internal
+ */
+export function singleContainerName(
+  taskDefinitionArgs: schema.FargateServiceTaskDefinitionInputs | undefined,
+): pulumi.Input<string> | undefined {
+  if (taskDefinitionArgs?.container !== undefined) {
+    return taskDefinitionArgs.container.name ?? "container";
+  }
+  const containerNames = Object.keys(taskDefinitionArgs?.containers ?? {});
+  return containerNames.length === 1 ? containerNames[0] : undefined;
+}
+
+/**
+ * Creates the application load balancers of the frontends of a service, with the bindings of
+ * their target groups to the containers of the service.
+ * @internal
+ */
+export function createFrontends(
+  name: string,
+  frontends: Record<string, schema.ServiceFrontendInputs>,
+  vpc: ServiceVpc,
+  defaultContainerName: pulumi.Input<string> | undefined,
+  parent: pulumi.Resource,
+): {
+  loadBalancers: Record<string, ApplicationLoadBalancer>;
+  bindings: aws.types.input.ecs.ServiceLoadBalancer[];
+} {
+  const loadBalancers: Record<string, ApplicationLoadBalancer> = {};
+  const bindings: aws.types.input.ecs.ServiceLoadBalancer[] = [];
+  for (const [key, frontend] of Object.entries(frontends)) {
+    const containerName = frontend.containerName ?? defaultContainerName;
+    if (containerName === undefined) {
+      throw new Error(
+        `[containerName] of frontend "${key}" must be specified if the task definition doesn't have a single container`,
+      );
+    }
+    const subnetType = frontend.subnetType ?? "Public";
+    const loadBalancer = new ApplicationLoadBalancer(
+      `${name}-${key}`,
+      {
+        subnetIds: frontendSubnetIds(vpc, subnetType),
+        internal: subnetType !== "Public",
+        defaultTargetGroup: {
+          vpcId: vpc.vpcId,
+          targetType: "ip",
+          protocol: "HTTP",
+          port: frontend.containerPort,
+          healthCheck: frontend.healthCheck,
+        },
+        listener: { port: frontend.port ?? 80, protocol: "HTTP" },
+      },
+      { parent },
+    );
+    loadBalancers[key] = loadBalancer;
+    bindings.push({
+      targetGroupArn: pulumi.output(loadBalancer.defaultTargetGroup).arn,
+      containerName,
+      containerPort: frontend.containerPort,
+    });
+  }
+  return { loadBalancers, bindings };
+}
+
+function frontendSubnetIds(
+  vpc: ServiceVpc,
+  subnetType: schema.SubnetTypeInputs,
+): pulumi.Output<string[]> {
+  switch (subnetType) {
+    case "Public":
+      return vpc.publicSubnetIds;
+    case "Private":
+      return vpc.privateSubnetIds;
+    case "Isolated":
+      return vpc.isolatedSubnetIds;
+    default:
+      throw new Error(`Frontends can't be placed in subnets of type ${subnetType}`);
+  }
+}
@@ -0,0 +1,144 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+import * as pulumi from "@pulumi/pulumi";
+
+import { ApplicationLoadBalancer } from "../lb/applicationLoadBalancer";
+import { mockResources } from "../tests/mocks";
+import { FargateService } from "./fargateService";
+
+function promiseOf<T>(output: pulumi.Output<T>): Promise<T> {
+  return new Promise((resolve) => output.apply(resolve));
+}
+
+describe("load balancer security groups", () => {
+  // The target group "arn:tg" is attached to a load balancer with the security group "sg-web".
+  const { registeredResource } = mockResources({
+    call: (args) => {
+      switch (args.token) {
+        case "aws:lb/getTargetGroup:getTargetGroup":
+          return { loadBalancerArns: args.inputs.arn === "arn:tg" ? ["arn:web"] : [] };
+        case "aws:lb/getLoadBalancer:getLoadBalancer":
+          return { securityGroups: args.inputs.arn === "arn:web" ? ["sg-web"] : [] };
+        default:
+          return undefined;
+      }
+    },
+  });
+
+  it("allows traffic from the load balancer on the container port", async () => {
+    const service = new FargateService("api", {
+      cluster: "cluster-arn",
+      taskDefinition: "task-definition-arn",
+      loadBalancers: [{ targetGroupArn: "arn:tg", containerPort: 8080 }],
+      networkConfiguration: { subnets: ["subnet-a"], securityGroups: ["sg-svc"] },
+      loadBalancerSecurityGroups: [{ securityGroupId: "sg-web" }],
+    });
+
+    const securityGroup = await registeredResource("aws:ec2/securityGroup:SecurityGroup", "api-lb");
+    expect(securityGroup.inputs).toMatchObject({
+      vpcId: "vpc-123",
+      ingress: [{ protocol: "tcp", fromPort: 8080, toPort: 8080, securityGroups: ["sg-web"] }],
+    });
+    const egress = await registeredResource(
+      "aws:ec2/securityGroupRule:SecurityGroupRule",
+      "api-lb-0-egress-8080",
+    );
+    expect(egress.inputs).toMatchObject({
+      type: "egress",
+      securityGroupId: "sg-web",
+      sourceSecurityGroupId: "api-lb-id",
+      protocol: "tcp",
+      fromPort: 8080,
+      toPort: 8080,
+    });
+    const networkConfiguration = await promiseOf(service.service.networkConfiguration);
+    expect(networkConfiguration?.securityGroups).toEqual(["sg-svc", "api-lb-id"]);
+  });
+
+  it("doesn't add an egress rule to security groups with inline rules", async () => {
+    const lb = new ApplicationLoadBalancer("open", { subnetIds: ["subnet-a"] });
+    new FargateService("worker", {
+      cluster: "cluster-arn",
+      taskDefinition: "task-definition-arn",
+      loadBalancers: [{ targetGroupArn: lb.defaultTargetGroup.arn, containerPort: 8080 }],
+      networkConfiguration: { subnets: ["subnet-a"], securityGroups: ["sg-svc"] },
+      loadBalancerSecurityGroups: [
+        { securityGroupId: pulumi.output(lb.defaultSecurityGroup!).id, egress: false },
+      ],
+    });
+
+    const securityGroup = await registeredResource(
+      "aws:ec2/securityGroup:SecurityGroup",
+      "worker-lb",
+    );
+    expect(securityGroup.inputs.ingress).toEqual([
+      expect.objectContaining({ fromPort: 8080, securityGroups: ["open-id"] }),
+    ]);
+    await expect(
+      registeredResource("aws:ec2/securityGroupRule:SecurityGroupRule", "worker-lb-0-egress-8080"),
+    ).rejects.toThrow();
+  });
+
+  it("adds an egress rule per container port", async () => {
+    new FargateService("admin", {
+      cluster: "cluster-arn",
+      taskDefinition: "task-definition-arn",
+      loadBalancers: [
+        { targetGroupArn: "arn:tg", containerPort: 8080 },
+        { targetGroupArn: "arn:tg", containerPort: 9090 },
+      ],
+      networkConfiguration: { subnets: ["subnet-a"] },
+      loadBalancerSecurityGroups: [{ securityGroupId: "sg-web" }],
+    });
+
+    for (const port of [8080, 9090]) {
+      const egress = await registeredResource(
+        "aws:ec2/securityGroupRule:SecurityGroupRule",
+        `admin-lb-0-egress-${port}`,
+      );
+      expect(egress.inputs).toMatchObject({ fromPort: port, toPort: port });
+    }
+  });
+
+  it("allows traffic from the load balancers of the target groups", async () => {
+    new FargateService("detected", {
+      cluster: "cluster-arn",
+      taskDefinition: "task-definition-arn",
+      loadBalancers: [{ targetGroupArn: "arn:tg", containerPort: 8080 }],
+      networkConfiguration: { subnets: ["subnet-a"] },
+    });
+
+    const securityGroup = await registeredResource(
+      "aws:ec2/securityGroup:SecurityGroup",
+      "detected-lb",
+    );
+    expect(securityGroup.inputs.ingress).toEqual([
+      expect.objectContaining({ fromPort: 8080, toPort: 8080, securityGroups: ["sg-web"] }),
+    ]);
+    const ecsService = await registeredResource("aws:ecs/service:Service", "detected");
+    expect(ecsService.inputs.networkConfiguration.securityGroups).toEqual(["detected-lb-id"]);
+  });
+
+  it("requires load balancers", () => {
+    expect(
+      () =>
+        new FargateService("invalid", {
+          cluster: "cluster-arn",
+          taskDefinition: "task-definition-arn",
+          networkConfiguration: { subnets: ["subnet-a"] },
+          loadBalancerSecurityGroups: [{ securityGroupId: "sg-web" }],
+        }),
+    ).toThrow("[loadBalancerSecurityGroups] requires [loadBalancers]");
+  });
+});
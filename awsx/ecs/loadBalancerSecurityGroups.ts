@@ -0,0 +1,187 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import * as aws from "@pulumi/aws";
+import * as pulumi from "@pulumi/pulumi";
+import * as schema from "../schema-types";
+import * as utils from "../utils";
+
+/**
+ * Creates a security group for the service allowing traffic from the security groups of its load
+ * balancers on the container ports of the [loadBalancers]. The security groups are the given
+ * [loadBalancerSecurityGroups] and, if [detect] is set, the security groups of the load balancers
+ * the target groups of the [loadBalancers] are attached to. An egress rule per container port is
+ * added to the given security groups unless disabled. Load balancer security groups defining their
+ * rules inline, like the default security group of an ApplicationLoadBalancer, must disable the
+ * egress rules. Detected security groups must already allow egress to the service.
+ * @internal
+ */
+export function allowLoadBalancerTraffic(
+  name: string,
+  loadBalancerSecurityGroups: schema.ServiceLoadBalancerSecurityGroupInputs[],
+  detect: boolean,
+  loadBalancers: pulumi.Input<pulumi.Input<aws.types.input.ecs.ServiceLoadBalancer>[]> | undefined,
+  networkConfiguration: pulumi.Input<aws.types.input.ecs.ServiceNetworkConfiguration> | undefined,
+  region: pulumi.Input<string> | undefined,
+  opts: { parent: pulumi.Resource; dependsOn?: pulumi.ComponentResourceOptions["dependsOn"] },
+): aws.ec2.SecurityGroup | undefined {
+  const { parent } = opts;
+  if (loadBalancerSecurityGroups.length > 0) {
+    if (loadBalancers === undefined) {
+      throw new Error(
+        "[loadBalancerSecurityGroups] requires [loadBalancers] or port mappings with a [targetGroup]",
+      );
+    }
+    if (networkConfiguration === undefined) {
+      throw new Error("[loadBalancerSecurityGroups] requires [networkConfiguration]");
+    }
+  } else if (!detect || loadBalancers === undefined || networkConfiguration === undefined) {
+    return undefined;
+  }
+
+  const ports = pulumi
+    .output(loadBalancers)
+    .apply((lbs) => [...new Set(lbs.map((lb) => lb.containerPort))].sort((a, b) => a - b));
+  const sourceSecurityGroupIds = pulumi
+    .all([
+      pulumi.all(loadBalancerSecurityGroups.map((sg) => sg.securityGroupId)),
+      detect ? targetGroupLoadBalancerSecurityGroups(loadBalancers, region, opts) : [],
+    ])
+    .apply(([given, detected]) => [...new Set([...given, ...detected])]);
+  const vpcId = pulumi
+    .output(networkConfiguration)
+    .apply((config) => aws.ec2.getSubnetOutput({ id: config.subnets[0], region }, { parent }))
+    .apply((subnet) => subnet.vpcId);
+
+  const securityGroup = new aws.ec2.SecurityGroup(
+    `${name}-lb`,
+    {
+      region,
+      vpcId,
+      description: "Allow traffic from the load balancers of the service",
+      ingress: pulumi.all([ports, sourceSecurityGroupIds]).apply(([ports, ids]) =>
+        ids.length === 0
+          ? []
+          : ports.map((port) => ({
+              protocol: "tcp",
+              fromPort: port,
+              toPort: port,
+              securityGroups: ids,
+            })),
+      ),
+    },
+    { parent },
+  );
+
+  loadBalancerSecurityGroups.forEach((loadBalancerSecurityGroup, i) => {
+    if (loadBalancerSecurityGroup.egress === false) {
+      return;
+    }
+    // The container ports are only known once the load balancers are. The rules are named after
+    // their port, so that changing the ports leaves the rules of the other ports in place.
+    ports.apply((ports) =>
+      ports.map(
+        (port, j) =>
+          new aws.ec2.SecurityGroupRule(
+            `${name}-lb-${i}-egress-${port}`,
+            {
+              region,
+              type: "egress",
+              securityGroupId: loadBalancerSecurityGroup.securityGroupId,
+              sourceSecurityGroupId: securityGroup.id,
+              protocol: "tcp",
+              fromPort: port,
+              toPort: port,
+            },
+            // The first rule used to cover the range of all ports.
+            { parent, aliases: j === 0 ? [{ name: `${name}-lb-${i}-egress` }] : undefined },
+          ),
+      ),
+    );
+  });
+
+  return securityGroup;
+}
+
+/**
+ * Whether the service forwards traffic from load balancers, given by [loadBalancers] or port
+ * mappings with a target group. Port mappings that aren't known yet are assumed to have one.
+ * @internal
+ */
+export function hasLoadBalancers(
+  loadBalancers: pulumi.Input<pulumi.Input<aws.types.input.ecs.ServiceLoadBalancer>[]> | undefined,
+  taskDefinitionArgs:
+    | {
+        container?: schema.TaskDefinitionContainerDefinitionInputs;
+        containers?: Record<string, schema.TaskDefinitionContainerDefinitionInputs>;
+      }
+    | undefined,
+): boolean {
+  if (loadBalancers !== undefined) {
+    return true;
+  }
+  const containers = [
+    ...(taskDefinitionArgs?.container !== undefined ? [taskDefinitionArgs.container] : []),
+    ...Object.values(taskDefinitionArgs?.containers ?? {}),
+  ];
+  return containers.some(
+    ({ portMappings }) =>
+      portMappings !== undefined &&
+      (!Array.isArray(portMappings) ||
+        portMappings.some((m) => pulumi.Output.isInstance(m) || m.targetGroup !== undefined)),
+  );
+}
+
+/**
+ * Looks up the security groups of the load balancers the target groups of the [loadBalancers] are
+ * attached to. ECS requires the target groups to be attached before the service is created, so the
+ * lookups wait for the dependencies of the service.
+ */
+function targetGroupLoadBalancerSecurityGroups(
+  loadBalancers: pulumi.Input<pulumi.Input<aws.types.input.ecs.ServiceLoadBalancer>[]>,
+  region: pulumi.Input<string> | undefined,
+  opts: pulumi.InvokeOutputOptions,
+): pulumi.Output<string[]> {
+  const securityGroupsOf = (targetGroupArn: string) =>
+    aws.lb
+      .getTargetGroupOutput({ arn: targetGroupArn, region }, opts)
+      .loadBalancerArns.apply((arns) =>
+        pulumi.all(
+          arns.map((arn) => aws.lb.getLoadBalancerOutput({ arn, region }, opts).securityGroups),
+        ),
+      );
+  return pulumi
+    .output(loadBalancers)
+    .apply((lbs) => pulumi.all(utils.choose(lbs, (lb) => lb.targetGroupArn).map(securityGroupsOf)))
+    .apply((groups) => groups.flat(2));
+}
+
+/**
+ * Adds the security group allowing load balancer traffic to the service's network configuration.
+ * @internal
+ */
+export function withLoadBalancerIngressSecurityGroup(
+  networkConfiguration: pulumi.Input<aws.types.input.ecs.ServiceNetworkConfiguration>,
+  securityGroup: aws.ec2.SecurityGroup | undefined,
+): pulumi.Input<aws.types.input.ecs.ServiceNetworkConfiguration> {
+  if (securityGroup === undefined) {
+    return networkConfiguration;
+  }
+  return pulumi
+    .all([pulumi.output(networkConfiguration), securityGroup.id])
+    .apply(([config, id]) => ({
+      ...config,
+      securityGroups: [...(config.securityGroups ?? []), id],
+    }));
+}
@@ -0,0 +1,119 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+import * as pulumi from "@pulumi/pulumi";
+
+import { mockResources } from "../tests/mocks";
+import { EC2TaskDefinition } from "./ec2TaskDefinition";
+import { mergeSecrets, secretValueFrom } from "./secrets";
+
+function promiseOf<T>(output: pulumi.Output<T>): Promise<T> {
+  return new Promise((resolve) => output.apply(resolve));
+}
+
+const secretArn = "arn:aws:secretsmanager:us-west-2:123456789012:secret:db-AbCdEf";
+
+describe("secretValueFrom", () => {
+  it("uses the secret ARN as is without a key or version", () => {
+    expect(secretValueFrom({ secretArn })).toBe(secretArn);
+  });
+
+  it("appends the JSON key and version", () => {
+    expect(secretValueFrom({ secretArn, jsonKey: "password", versionStage: "AWSPREVIOUS" })).toBe(
+      `${secretArn}:password:AWSPREVIOUS:`,
+    );
+  });
+
+  it("uses the parameter name", () => {
+    expect(secretValueFrom({ parameterName: "/app/token" })).toBe("/app/token");
+  });
+});
+
+describe("mergeSecrets", () => {
+  it("appends the secret sources to the secrets", () => {
+    expect(
+      mergeSecrets("app", [{ name: "A", valueFrom: "a" }], { B: { parameterName: "b" } }),
+    ).toEqual([
+      { name: "A", valueFrom: "a" },
+      { name: "B", valueFrom: "b" },
+    ]);
+  });
+
+  it("rejects names used in both secrets and secret sources", () => {
+    expect(() =>
+      mergeSecrets("app", [{ name: "A", valueFrom: "a" }], { A: { parameterName: "a" } }),
+    ).toThrow(`Secret "A" of container "app" is specified in both [secrets] and [secretSources].`);
+  });
+});
+
+describe("secret sources", () => {
+  const { registeredResource } = mockResources();
+
+  it("renders the secrets and grants the execution role access", async () => {
+    const taskDefinition = new EC2TaskDefinition("secrets", {
+      container: {
+        name: "app",
+        image: "app-image",
+        secretSources: {
+          DB_PASSWORD: { secretArn, jsonKey: "password" },
+          API_TOKEN: {
+            parameterName: "/app/token",
+            kmsKeyArn: "arn:aws:kms:us-west-2:123456789012:key/abc",
+          },
+        },
+      },
+    });
+
+    const containerDefinitions = await promiseOf(
+      taskDefinition.taskDefinition.containerDefinitions,
+    );
+    const [container] = JSON.parse(containerDefinitions);
+    expect(container.secretSources).toBeUndefined();
+    expect(container.secrets).toEqual([
+      { name: "DB_PASSWORD", valueFrom: `${secretArn}:password::` },
+      { name: "API_TOKEN", valueFrom: "/app/token" },
+    ]);
+
+    const rolePolicy = await registeredResource(
+      "aws:iam/rolePolicy:RolePolicy",
+      "secrets-execution-secrets",
+    );
+    const policy = JSON.parse(rolePolicy.inputs.policy);
+    expect(policy.Statement).toEqual([
+      {
+        Effect: "Allow",
+        Action: ["secretsmanager:GetSecretValue"],
+        Resource: [secretArn, `${secretArn}-??????`],
+      },
+      {
+        Effect: "Allow",
+        Action: ["ssm:GetParameters"],
+        Resource: ["arn:aws:ssm:us-west-2:123456789012:parameter/app/token"],
+      },
+      {
+        Effect: "Allow",
+        Action: ["kms:Decrypt"],
+        Resource: ["arn:aws:kms:us-west-2:123456789012:key/abc"],
+      },
+    ]);
+  });
+
+  it("requires exactly one of secretArn or parameterName", () => {
+    expect(
+      () =>
+        new EC2TaskDefinition("invalid", {
+          container: { name: "app", image: "app-image", secretSources: { A: {} } },
+        }),
+    ).toThrow("Exactly one of [secretArn] or [parameterName] must be provided");
+  });
+});
@@ -0,0 +1,148 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import * as aws from "@pulumi/aws";
+import * as pulumi from "@pulumi/pulumi";
+import * as schema from "../schema-types";
+import { getRegion } from "../utils";
+
+type SecretSource = pulumi.Unwrap<schema.TaskDefinitionSecretSourceInputs>;
+type Secret = pulumi.Unwrap<schema.TaskDefinitionSecretInputs>;
+
+/**
+ * Renders the `valueFrom` reference ECS uses to fetch the secret or parameter.
+ * @internal
+ */
+export function secretValueFrom(source: SecretSource): string {
+  const { secretArn, jsonKey, versionStage, versionId } = source;
+  if (secretArn === undefined) {
+    return source.parameterName!;
+  }
+  if (jsonKey === undefined && versionStage === undefined && versionId === undefined) {
+    return secretArn;
+  }
+  // arn:aws:secretsmanager:region:account:secret:name:json-key:version-stage:version-id
+  return [secretArn, jsonKey ?? "", versionStage ?? "", versionId ?? ""].join(":");
+}
+
+/**
+ * Merges the container's [secretSources] into its [secrets].
+ * @internal
+ */
+export function mergeSecrets(
+  containerName: string,
+  secrets: Secret[] | undefined,
+  secretSources: Record<string, SecretSource> | undefined,
+): Secret[] | undefined {
+  if (secretSources === undefined) {
+    return secrets;
+  }
+  const merged = [...(secrets ?? [])];
+  for (const [name, source] of Object.entries(secretSources)) {
+    if (merged.some((s) => s.name === name)) {
+      throw new Error(
+        `Secret "${name}" of container "${containerName}" is specified in both [secrets] and [secretSources].`,
+      );
+    }
+    merged.push({ name, valueFrom: secretValueFrom(source) });
+  }
+  return merged;
+}
+
+/**
+ * Collects the statements the execution role needs to read the secrets of the containers.
+ * @internal
+ */
+export function secretGrants(
+  parent: pulumi.Resource,
+  args: schema.FargateTaskDefinitionArgs | schema.EC2TaskDefinitionArgs,
+): pulumi.Input<aws.types.input.iam.PolicyStatement>[] {
+  // The container names are only used for error messages, so a single container doesn't need to
+  // be resolved to its actual name.
+  const containers = args.containers ?? (args.container ? { container: args.container } : {});
+  const secretArns: pulumi.Input<string>[] = [];
+  const parameterArns: pulumi.Input<string>[] = [];
+  const kmsKeyArns: pulumi.Input<string>[] = [];
+  for (const [containerName, container] of Object.entries(containers)) {
+    for (const [name, source] of Object.entries(container.secretSources ?? {})) {
+      validateSecretSource(containerName, name, source);
+      if (source.secretArn !== undefined) {
+        // Partial ARNs omit the random suffix Secrets Manager appends to the secret name.
+        secretArns.push(source.secretArn, pulumi.interpolate`${source.secretArn}-??????`);
+      } else {
+        parameterArns.push(ssmParameterArn(parent, source.parameterName!, args.region));
+      }
+      if (source.kmsKeyArn !== undefined) {
+        kmsKeyArns.push(source.kmsKeyArn);
+      }
+    }
+  }
+
+  const statements: aws.types.input.iam.PolicyStatement[] = [];
+  if (secretArns.length > 0) {
+    statements.push({
+      Effect: "Allow",
+      Action: ["secretsmanager:GetSecretValue"],
+      Resource: secretArns,
+    });
+  }
+  if (parameterArns.length > 0) {
+    statements.push({ Effect: "Allow", Action: ["ssm:GetParameters"], Resource: parameterArns });
+  }
+  if (kmsKeyArns.length > 0) {
+    statements.push({ Effect: "Allow", Action: ["kms:Decrypt"], Resource: kmsKeyArns });
+  }
+  return statements;
+}
+
+function validateSecretSource(
+  containerName: string,
+  name: string,
+  source: schema.TaskDefinitionSecretSourceInputs,
+) {
+  const location = `secret "${name}" of container "${containerName}"`;
+  if ((source.secretArn === undefined) === (source.parameterName === undefined)) {
+    throw new Error(
+      `Exactly one of [secretArn] or [parameterName] must be provided for ${location}`,
+    );
+  }
+  if (
+    source.parameterName !== undefined &&
+    (source.jsonKey !== undefined ||
+      source.versionStage !== undefined ||
+      source.versionId !== undefined)
+  ) {
+    throw new Error(
+      `[jsonKey], [versionStage] and [versionId] can only be used with [secretArn] for ${location}`,
+    );
+  }
+}
+
+function ssmParameterArn(
+  parent: pulumi.Resource,
+  parameterName: pulumi.Input<string>,
+  region: pulumi.Input<string> | undefined,
+): pulumi.Output<string> {
+  return pulumi.output(parameterName).apply((name) => {
+    if (name.startsWith("arn:")) {
+      return pulumi.output(name);
+    }
+    const { partition } = aws.getPartitionOutput({}, { parent });
+    const { accountId } = aws.getCallerIdentityOutput({}, { parent });
+    const parameterRegion = region ?? getRegion(parent);
+    // Parameter names in hierarchies start with a slash which isn't repeated in the ARN.
+    const path = name.replace(/^\//, "");
+    return pulumi.interpolate`arn:${partition}:ssm:${parameterRegion}:${accountId}:parameter/${path}`;
+  });
+}
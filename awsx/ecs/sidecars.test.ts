@@ -0,0 +1,205 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+import * as pulumi from "@pulumi/pulumi";
+
+import { applySidecars, sidecarGrants } from "./sidecars";
+
+function promiseOf<T>(output: pulumi.Output<T>): Promise<T> {
+  return new Promise((resolve) => output.apply(resolve));
+}
+
+const logGroupId = pulumi.output({
+  logGroupName: "my-log-group",
+  logGroupRegion: "us-west-2",
+  arn: "arn:aws:logs:us-west-2:123456789012:log-group:my-log-group",
+});
+
+const mainContainers = pulumi.output({
+  app: { name: "app", image: "app-image" },
+});
+
+describe("sidecars", () => {
+  it("routes main container logs through FireLens", async () => {
+    const containers = applySidecars(
+      mainContainers,
+      [{ preset: "FireLens" }],
+      logGroupId,
+      "FARGATE",
+    );
+    const resolved = await promiseOf(pulumi.output(containers));
+
+    expect(resolved.log_router).toMatchObject({
+      name: "log_router",
+      essential: true,
+      firelensConfiguration: { type: "fluentbit" },
+    });
+    expect(resolved.app).toMatchObject({
+      dependsOn: [{ containerName: "log_router", condition: "START" }],
+      logConfiguration: {
+        logDriver: "awsfirelens",
+        options: {
+          Name: "cloudwatch_logs",
+          region: "us-west-2",
+          log_group_name: "my-log-group",
+          log_stream_prefix: "app/",
+        },
+      },
+    });
+  });
+
+  it("keeps explicit environment variables of the main containers", async () => {
+    const containers = applySidecars(
+      pulumi.output({
+        app: {
+          name: "app",
+          image: "app-image",
+          environment: [{ name: "AWS_XRAY_DAEMON_ADDRESS", value: "xray:2000" }],
+        },
+        worker: { name: "worker", image: "worker-image" },
+      }),
+      [{ preset: "XRay" }],
+      logGroupId,
+      "FARGATE",
+    );
+    const resolved = await promiseOf(pulumi.output(containers));
+
+    expect(resolved.app.environment).toEqual([
+      { name: "AWS_XRAY_DAEMON_ADDRESS", value: "xray:2000" },
+    ]);
+    expect(resolved.worker.environment).toEqual([
+      { name: "AWS_XRAY_DAEMON_ADDRESS", value: "127.0.0.1:2000" },
+    ]);
+    expect(resolved["xray-daemon"].portMappings).toEqual([
+      { containerPort: 2000, protocol: "udp" },
+    ]);
+  });
+
+  it("only applies the preset to the selected containers", async () => {
+    const containers = applySidecars(
+      pulumi.output({
+        app: { name: "app", image: "app-image" },
+        worker: { name: "worker", image: "worker-image" },
+      }),
+      [{ preset: "CloudWatchAgent", containerNames: ["worker"] }],
+      logGroupId,
+      "EC2",
+    );
+    const resolved = await promiseOf(pulumi.output(containers));
+
+    expect(resolved.app.environment).toBeUndefined();
+    expect(resolved.worker.environment).toEqual([
+      { name: "AWS_EMF_AGENT_ENDPOINT", value: "tcp://127.0.0.1:25888" },
+    ]);
+  });
+
+  it("links the main containers to the agent in bridge mode", async () => {
+    const containers = applySidecars(
+      mainContainers,
+      [{ preset: "XRay" }],
+      undefined,
+      "EC2",
+      "bridge",
+    );
+    const resolved = await promiseOf(pulumi.output(containers));
+
+    expect(resolved.app).toMatchObject({
+      links: ["xray-daemon"],
+      environment: [{ name: "AWS_XRAY_DAEMON_ADDRESS", value: "xray-daemon:2000" }],
+    });
+  });
+
+  it("reaches the agent on the loopback interface in awsvpc mode", async () => {
+    const containers = applySidecars(mainContainers, [{ preset: "XRay" }], undefined, "EC2");
+    const resolved = await promiseOf(pulumi.output(containers));
+
+    expect(resolved.app.links).toBeUndefined();
+    expect(resolved.app).toMatchObject({
+      environment: [{ name: "AWS_XRAY_DAEMON_ADDRESS", value: "127.0.0.1:2000" }],
+    });
+  });
+
+  it("rejects agents in tasks without networking", () => {
+    expect(() =>
+      applySidecars(
+        mainContainers,
+        [{ preset: "Datadog", datadogApiKeySecretArn: "arn" }],
+        undefined,
+        "EC2",
+        "none",
+      ),
+    ).toThrow(`Sidecar "datadog-agent" can't receive telemetry`);
+  });
+
+  it("pins the default images to versions", async () => {
+    const containers = applySidecars(
+      mainContainers,
+      [
+        { preset: "FireLens" },
+        { preset: "XRay" },
+        { preset: "Datadog", datadogApiKeySecretArn: "arn" },
+        { preset: "CloudWatchAgent" },
+      ],
+      logGroupId,
+      "FARGATE",
+    );
+    const resolved = await promiseOf(pulumi.output(containers));
+
+    for (const name of ["log_router", "xray-daemon", "datadog-agent", "cloudwatch-agent"]) {
+      expect(resolved[name].image).toMatch(/:\d+(\.\w+)+$/);
+    }
+  });
+
+  it("requires an API key secret for Datadog", () => {
+    expect(() =>
+      applySidecars(mainContainers, [{ preset: "Datadog" }], logGroupId, "FARGATE"),
+    ).toThrow("[datadogApiKeySecretArn]");
+  });
+
+  it("requires FireLens options when the log group is skipped", () => {
+    expect(() =>
+      applySidecars(mainContainers, [{ preset: "FireLens" }], undefined, "FARGATE"),
+    ).toThrow("[firelensOptions]");
+  });
+
+  it("rejects duplicate sidecar names", () => {
+    expect(() =>
+      applySidecars(
+        mainContainers,
+        [{ preset: "XRay" }, { preset: "XRay" }],
+        logGroupId,
+        "FARGATE",
+      ),
+    ).toThrow(`"xray-daemon" is used more than once`);
+  });
+
+  it("collects the role grants of all sidecars", async () => {
+    const grants = sidecarGrants(
+      [
+        { preset: "XRay" },
+        { preset: "Datadog", datadogApiKeySecretArn: "arn:aws:secretsmanager:secret" },
+      ],
+      logGroupId,
+    );
+
+    expect(grants.taskRoleManagedPolicies).toEqual(["AWSXRayDaemonWriteAccess"]);
+    expect(grants.taskRoleStatements).toHaveLength(1);
+    expect(grants.executionRoleStatements).toEqual([
+      {
+        Effect: "Allow",
+        Action: ["secretsmanager:GetSecretValue"],
+        Resource: "arn:aws:secretsmanager:secret",
+      },
+    ]);
+  });
+});
@@ -0,0 +1,389 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import * as aws from "@pulumi/aws";
+import * as pulumi from "@pulumi/pulumi";
+import { LogGroupId } from "../cloudwatch/logGroup";
+import * as schema from "../schema-types";
+
+type ContainerDefinitions = Record<string, schema.TaskDefinitionContainerDefinitionInputs>;
+
+/** The permissions a sidecar needs on the task and execution roles. */
+export interface SidecarGrants {
+  /** Names of the AWS managed policies to attach to the task role. */
+  taskRoleManagedPolicies: string[];
+  taskRoleStatements: pulumi.Input<aws.types.input.iam.PolicyStatement>[];
+  executionRoleStatements: pulumi.Input<aws.types.input.iam.PolicyStatement>[];
+}
+
+/** The main container a preset is applied to. */
+interface SidecarTarget {
+  containerName: string;
+  sidecar: schema.TaskDefinitionSidecarInputs;
+  logGroupId: pulumi.Input<LogGroupId> | undefined;
+  /** The host the main container reaches the sidecar's agent at. */
+  agentHost: string;
+}
+
+interface SidecarPreset {
+  defaultName: string;
+  defaultImage: string;
+  /** Whether the main containers send telemetry to an agent in the sidecar. */
+  agent: boolean;
+  /** Build the sidecar container itself. */
+  container(
+    sidecar: schema.TaskDefinitionSidecarInputs,
+    launchType: "FARGATE" | "EC2",
+  ): Partial<schema.TaskDefinitionContainerDefinitionInputs>;
+  /** Apply the preset's changes to one of the main containers. */
+  apply(
+    container: schema.TaskDefinitionContainerDefinitionInputs,
+    target: SidecarTarget,
+  ): schema.TaskDefinitionContainerDefinitionInputs;
+  grants(
+    sidecar: schema.TaskDefinitionSidecarInputs,
+    logGroupId: pulumi.Input<LogGroupId> | undefined,
+  ): SidecarGrants;
+}
+
+// Containers of an `awsvpc` or `host` task share their network namespace, so the agents are
+// reached on the loopback interface. In `bridge` mode the main containers are linked to the
+// agent and reach it by its container name.
+const loopbackAddress = "127.0.0.1";
+
+const defaultCloudwatchAgentConfig = JSON.stringify({
+  logs: { metrics_collected: { emf: {} } },
+  metrics: { metrics_collected: { statsd: {} } },
+});
+
+const presets: Record<schema.TaskDefinitionSidecarPresetInputs, SidecarPreset> = {
+  FireLens: {
+    defaultName: "log_router",
+    defaultImage: "public.ecr.aws/aws-observability/aws-for-fluent-bit:2.32.4",
+    agent: false,
+    container: () => ({
+      essential: true,
+      firelensConfiguration: { type: "fluentbit" },
+    }),
+    apply: (container, { containerName, sidecar, logGroupId }) => {
+      if (container.logConfiguration !== undefined) {
+        // An explicit log configuration always wins over the preset.
+        return container;
+      }
+      return {
+        ...container,
+        logConfiguration: {
+          logDriver: "awsfirelens",
+          options: firelensOptions(containerName, sidecar, logGroupId),
+        },
+      };
+    },
+    grants: (sidecar, logGroupId) => ({
+      taskRoleManagedPolicies: [],
+      // Custom options can route the logs anywhere, so we only know which permissions are
+      // needed when shipping to the task definition's log group.
+      taskRoleStatements:
+        sidecar.firelensOptions === undefined && logGroupId !== undefined
+          ? [
+              {
+                Effect: "Allow",
+                Action: ["logs:CreateLogStream", "logs:DescribeLogStreams", "logs:PutLogEvents"],
+                Resource: pulumi.output(logGroupId).apply((id) => `${id.arn}:*`),
+              },
+            ]
+          : [],
+      executionRoleStatements: [],
+    }),
+  },
+  XRay: {
+    defaultName: "xray-daemon",
+    defaultImage: "public.ecr.aws/xray/aws-xray-daemon:3.3.14",
+    agent: true,
+    container: () => ({
+      essential: false,
+      portMappings: [{ containerPort: 2000, protocol: "udp" }],
+    }),
+    apply: (container, { agentHost }) =>
+      withEnvironment(container, { AWS_XRAY_DAEMON_ADDRESS: `${agentHost}:2000` }),
+    grants: () => ({
+      taskRoleManagedPolicies: ["AWSXRayDaemonWriteAccess"],
+      taskRoleStatements: [],
+      executionRoleStatements: [],
+    }),
+  },
+  Datadog: {
+    defaultName: "datadog-agent",
+    defaultImage: "public.ecr.aws/datadog/agent:7.57.2",
+    agent: true,
+    container: (sidecar, launchType) => ({
+      essential: false,
+      portMappings: [
+        { containerPort: 8126, protocol: "tcp" },
+        { containerPort: 8125, protocol: "udp" },
+      ],
+      environment: [
+        ...(launchType === "FARGATE" ? [{ name: "ECS_FARGATE", value: "true" }] : []),
+        { name: "DD_SITE", value: sidecar.datadogSite ?? "datadoghq.com" },
+        { name: "DD_APM_ENABLED", value: "true" },
+        { name: "DD_DOGSTATSD_NON_LOCAL_TRAFFIC", value: "true" },
+      ],
+      secrets: [{ name: "DD_API_KEY", valueFrom: sidecar.datadogApiKeySecretArn! }],
+    }),
+    apply: (container, { agentHost }) => withEnvironment(container, { DD_AGENT_HOST: agentHost }),
+    grants: (sidecar) => ({
+      taskRoleManagedPolicies: [],
+      taskRoleStatements: [
+        {
+          Effect: "Allow",
+          Action: [
+            "ecs:ListClusters",
+            "ecs:ListContainerInstances",
+            "ecs:DescribeContainerInstances",
+          ],
+          Resource: "*",
+        },
+      ],
+      executionRoleStatements: [
+        {
+          Effect: "Allow",
+          Action: ["secretsmanager:GetSecretValue"],
+          Resource: sidecar.datadogApiKeySecretArn!,
+        },
+      ],
+    }),
+  },
+  CloudWatchAgent: {
+    defaultName: "cloudwatch-agent",
+    defaultImage: "public.ecr.aws/cloudwatch-agent/cloudwatch-agent:1.300044.0b650",
+    agent: true,
+    container: (sidecar) => ({
+      essential: false,
+      portMappings: [
+        { containerPort: 25888, protocol: "tcp" },
+        { containerPort: 8125, protocol: "udp" },
+      ],
+      environment: [
+        {
+          name: "CW_CONFIG_CONTENT",
+          value: sidecar.cloudwatchAgentConfig ?? defaultCloudwatchAgentConfig,
+        },
+      ],
+    }),
+    apply: (container, { agentHost }) =>
+      withEnvironment(container, { AWS_EMF_AGENT_ENDPOINT: `tcp://${agentHost}:25888` }),
+    grants: () => ({
+      taskRoleManagedPolicies: ["CloudWatchAgentServerPolicy"],
+      taskRoleStatements: [],
+      executionRoleStatements: [],
+    }),
+  },
+};
+
+/** @internal */
+export function sidecarContainerName(sidecar: schema.TaskDefinitionSidecarInputs): string {
+  return sidecar.name ?? getPreset(sidecar).defaultName;
+}
+
+/**
+ * Injects the sidecar containers and applies each preset's changes to the main containers.
+ * @internal
+ */
+export function applySidecars(
+  containers: pulumi.Output<ContainerDefinitions>,
+  sidecars: schema.TaskDefinitionSidecarInputs[] | undefined,
+  logGroupId: pulumi.Input<LogGroupId> | undefined,
+  launchType: "FARGATE" | "EC2",
+  networkMode: pulumi.Input<string> = "awsvpc",
+): pulumi.Output<ContainerDefinitions> {
+  if (sidecars === undefined || sidecars.length === 0) {
+    return containers;
+  }
+  validateSidecars(sidecars, logGroupId, networkMode);
+
+  return pulumi.all([containers, networkMode]).apply(([mainContainers, networkMode]) => {
+    const mainNames = Object.keys(mainContainers);
+    const result: ContainerDefinitions = { ...mainContainers };
+    for (const sidecar of sidecars) {
+      const preset = getPreset(sidecar);
+      const sidecarName = sidecarContainerName(sidecar);
+      if (result[sidecarName] !== undefined) {
+        throw new Error(
+          `Sidecar container name "${sidecarName}" conflicts with an existing container.`,
+        );
+      }
+
+      const targets = sidecar.containerNames ?? mainNames;
+      for (const target of targets) {
+        const container = mainContainers[target];
+        if (container === undefined) {
+          throw new Error(
+            `Sidecar "${sidecarName}" refers to unknown container "${target}" in [containerNames].`,
+          );
+        }
+        let updated = result[target];
+        if (preset.agent) {
+          updated = withAgentLink(updated, sidecarName, networkMode);
+        }
+        updated = preset.apply(updated, {
+          containerName: target,
+          sidecar,
+          logGroupId,
+          agentHost: networkMode === "bridge" ? sidecarName : loopbackAddress,
+        });
+        result[target] = withDependency(updated, sidecarName);
+      }
+
+      result[sidecarName] = {
+        ...preset.container(sidecar, launchType),
+        name: sidecarName,
+        image: sidecar.image ?? preset.defaultImage,
+        cpu: sidecar.cpu,
+        memoryReservation: sidecar.memory,
+      };
+    }
+    return result;
+  });
+}
+
+/**
+ * Collects the permissions required by all sidecars.
+ * @internal
+ */
+export function sidecarGrants(
+  sidecars: schema.TaskDefinitionSidecarInputs[] | undefined,
+  logGroupId: pulumi.Input<LogGroupId> | undefined,
+): SidecarGrants {
+  const grants: SidecarGrants = {
+    taskRoleManagedPolicies: [],
+    taskRoleStatements: [],
+    executionRoleStatements: [],
+  };
+  for (const sidecar of sidecars ?? []) {
+    const presetGrants = getPreset(sidecar).grants(sidecar, logGroupId);
+    for (const policy of presetGrants.taskRoleManagedPolicies) {
+      if (!grants.taskRoleManagedPolicies.includes(policy)) {
+        grants.taskRoleManagedPolicies.push(policy);
+      }
+    }
+    grants.taskRoleStatements.push(...presetGrants.taskRoleStatements);
+    grants.executionRoleStatements.push(...presetGrants.executionRoleStatements);
+  }
+  return grants;
+}
+
+function getPreset(sidecar: schema.TaskDefinitionSidecarInputs): SidecarPreset {
+  const preset = presets[sidecar.preset];
+  if (preset === undefined) {
+    throw new Error(`Unknown sidecar preset "${sidecar.preset}".`);
+  }
+  return preset;
+}
+
+function validateSidecars(
+  sidecars: schema.TaskDefinitionSidecarInputs[],
+  logGroupId: pulumi.Input<LogGroupId> | undefined,
+  networkMode: pulumi.Input<string>,
+) {
+  const names = new Set<string>();
+  for (const sidecar of sidecars) {
+    const name = sidecarContainerName(sidecar);
+    if (names.has(name)) {
+      throw new Error(`Sidecar container name "${name}" is used more than once.`);
+    }
+    names.add(name);
+
+    // Computed network modes are checked once they are known.
+    if (typeof networkMode === "string" && getPreset(sidecar).agent) {
+      checkAgentNetworkMode(name, networkMode);
+    }
+
+    if (sidecar.preset === "Datadog" && sidecar.datadogApiKeySecretArn === undefined) {
+      throw new Error("The Datadog sidecar requires [datadogApiKeySecretArn].");
+    }
+    if (
+      sidecar.preset === "FireLens" &&
+      sidecar.firelensOptions === undefined &&
+      logGroupId === undefined
+    ) {
+      throw new Error(
+        "The FireLens sidecar requires [firelensOptions] when the log group creation is skipped.",
+      );
+    }
+  }
+}
+
+function firelensOptions(
+  containerName: string,
+  sidecar: schema.TaskDefinitionSidecarInputs,
+  logGroupId: pulumi.Input<LogGroupId> | undefined,
+): pulumi.Input<Record<string, pulumi.Input<string>>> {
+  if (sidecar.firelensOptions !== undefined) {
+    return sidecar.firelensOptions;
+  }
+  return pulumi.output(logGroupId!).apply((id) => ({
+    Name: "cloudwatch_logs",
+    region: id.logGroupRegion,
+    log_group_name: id.logGroupName,
+    log_stream_prefix: `${containerName}/`,
+    auto_create_group: "false",
+  }));
+}
+
+function withEnvironment(
+  container: schema.TaskDefinitionContainerDefinitionInputs,
+  variables: Record<string, string>,
+): schema.TaskDefinitionContainerDefinitionInputs {
+  const environment = pulumi.output(container.environment).apply((env) => {
+    const existing = env ?? [];
+    // Never override a variable that the user has set explicitly.
+    const added = Object.entries(variables)
+      .filter(([name]) => !existing.some((e) => e.name === name))
+      .map(([name, value]) => ({ name, value }));
+    return [...existing, ...added];
+  });
+  return { ...container, environment };
+}
+
+function withAgentLink(
+  container: schema.TaskDefinitionContainerDefinitionInputs,
+  sidecarName: string,
+  networkMode: string,
+): schema.TaskDefinitionContainerDefinitionInputs {
+  checkAgentNetworkMode(sidecarName, networkMode);
+  if (networkMode !== "bridge") {
+    return container;
+  }
+  const links = pulumi
+    .output(container.links)
+    .apply((links) => [...(links ?? []).filter((l) => l !== sidecarName), sidecarName]);
+  return { ...container, links };
+}
+
+function checkAgentNetworkMode(sidecarName: string, networkMode: string) {
+  if (networkMode === "none") {
+    throw new Error(
+      `Sidecar "${sidecarName}" can't receive telemetry from containers in [networkMode: none].`,
+    );
+  }
+}
+
+function withDependency(
+  container: schema.TaskDefinitionContainerDefinitionInputs,
+  sidecarName: string,
+): schema.TaskDefinitionContainerDefinitionInputs {
+  const dependsOn = pulumi
+    .output(container.dependsOn)
+    .apply((deps) => [...(deps ?? []), { containerName: sidecarName, condition: "START" }]);
+  return { ...container, dependsOn };
+}
@@ -0,0 +1,102 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+import * as aws from "@pulumi/aws";
+import * as pulumi from "@pulumi/pulumi";
+
+import { mockResources } from "../tests/mocks";
+import { FileSystem } from "./fileSystem";
+
+function promiseOf<T>(output: pulumi.Output<T>): Promise<T> {
+  return new Promise((resolve) => output.apply(resolve));
+}
+
+describe("FileSystem", () => {
+  const { registeredResource } = mockResources({
+    call: (args) =>
+      args.token === "aws:ec2/getSubnet:getSubnet"
+        ? { ...args.inputs, vpcId: "vpc-123", availabilityZone: `az-${args.inputs.id}` }
+        : undefined,
+  });
+
+  it("creates an encrypted file system with a mount target per subnet", async () => {
+    const fs = new FileSystem("data", { subnetIds: ["subnet-a", "subnet-b"] });
+    const mountTargets = fs.mountTargets as aws.efs.MountTarget[];
+    expect(mountTargets).toHaveLength(2);
+    const subnetIds = await Promise.all(mountTargets.map((mt) => promiseOf(mt.subnetId)));
+    expect(subnetIds).toEqual(["subnet-a", "subnet-b"]);
+
+    const mountTarget = await registeredResource("aws:efs/mountTarget:MountTarget", "data-1");
+    expect(mountTarget.inputs.securityGroups).toEqual(["data-id"]);
+
+    const fileSystem = await registeredResource("aws:efs/fileSystem:FileSystem", "data");
+    expect(fileSystem.inputs.encrypted).toBe(true);
+  });
+
+  it("allows NFS traffic from the client security group", async () => {
+    new FileSystem("shared", { subnetIds: ["subnet-a"] });
+
+    const ingress = await registeredResource(
+      "aws:vpc/securityGroupIngressRule:SecurityGroupIngressRule",
+      "shared-nfs",
+    );
+    expect(ingress.inputs).toMatchObject({
+      securityGroupId: "shared-id",
+      referencedSecurityGroupId: "shared-client-id",
+      ipProtocol: "tcp",
+      fromPort: 2049,
+      toPort: 2049,
+    });
+    const securityGroup = await registeredResource("aws:ec2/securityGroup:SecurityGroup", "shared");
+    expect(securityGroup.inputs.vpcId).toBe("vpc-123");
+  });
+
+  it("allows NFS traffic to a given security group from the client security group", async () => {
+    const fs = new FileSystem("given", {
+      subnetIds: ["subnet-a"],
+      defaultSecurityGroup: { securityGroupId: "sg-given" },
+    });
+    expect(fs.securityGroup).toBeUndefined();
+    expect(fs.clientSecurityGroup).toBeDefined();
+
+    const ingress = await registeredResource(
+      "aws:vpc/securityGroupIngressRule:SecurityGroupIngressRule",
+      "given-nfs",
+    );
+    expect(ingress.inputs).toMatchObject({
+      securityGroupId: "sg-given",
+      referencedSecurityGroupId: "given-client-id",
+      fromPort: 2049,
+      toPort: 2049,
+    });
+    const client = await registeredResource("aws:ec2/securityGroup:SecurityGroup", "given-client");
+    expect(client.inputs.vpcId).toBe("vpc-123");
+    const mountTarget = await registeredResource("aws:efs/mountTarget:MountTarget", "given-0");
+    expect(mountTarget.inputs.securityGroups).toEqual(["sg-given"]);
+  });
+
+  it("does not create security groups when skipped", () => {
+    const fs = new FileSystem("skipped", {
+      subnetIds: ["subnet-a"],
+      defaultSecurityGroup: { skip: true },
+    });
+    expect(fs.securityGroup).toBeUndefined();
+    expect(fs.clientSecurityGroup).toBeUndefined();
+  });
+
+  it("requires at least one subnet", () => {
+    expect(() => new FileSystem("empty", { subnetIds: [] })).toThrowError(
+      "At least one subnet must be specified in [subnetIds]",
+    );
+  });
+});
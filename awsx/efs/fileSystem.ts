@@ -0,0 +1,145 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import * as aws from "@pulumi/aws";
+import * as pulumi from "@pulumi/pulumi";
+import * as schema from "../schema-types";
+
+const nfsPort = 2049;
+
+/**
+ * An EFS file system with a mount target in each of the given subnets, which must be in distinct
+ * availability zones.
+ * Pass it to the `efsVolumes` of an ECS task definition to mount it into containers.
+ */
+export class FileSystem extends schema.FileSystem {
+  constructor(
+    name: string,
+    args: schema.FileSystemArgs,
+    opts: pulumi.ComponentResourceOptions = {},
+  ) {
+    super(name, {}, opts);
+    if (opts.urn) {
+      return; // Rehydrating, skip construction
+    }
+
+    const { subnetIds, defaultSecurityGroup, encrypted, region, ...fileSystemArgs } = args;
+    if (subnetIds.length === 0) {
+      throw new Error("At least one subnet must be specified in [subnetIds]");
+    }
+    const subnets = subnetIds.map((id) =>
+      aws.ec2.getSubnetOutput({ id, region }, { parent: this }),
+    );
+
+    const fileSystem = new aws.efs.FileSystem(
+      name,
+      {
+        ...fileSystemArgs,
+        encrypted: encrypted ?? true,
+        region,
+      },
+      { parent: this },
+    );
+    this.fileSystem = fileSystem;
+
+    let securityGroupIds: pulumi.Input<string>[] | undefined;
+    if (!defaultSecurityGroup?.skip) {
+      if (defaultSecurityGroup?.args && defaultSecurityGroup.securityGroupId) {
+        throw new Error(
+          "Only one of [defaultSecurityGroup] [args] or [securityGroupId] can be specified",
+        );
+      }
+      let securityGroupId: pulumi.Input<string>;
+      let vpcId: pulumi.Input<string> = subnets[0].vpcId;
+      if (defaultSecurityGroup?.securityGroupId) {
+        securityGroupId = defaultSecurityGroup.securityGroupId;
+      } else {
+        const securityGroup = new aws.ec2.SecurityGroup(
+          name,
+          defaultSecurityGroup?.args ?? { vpcId, region, tags: args.tags },
+          { parent: this },
+        );
+        this.securityGroup = securityGroup;
+        securityGroupId = securityGroup.id;
+        vpcId = securityGroup.vpcId;
+      }
+      // A given security group also gets the NFS rule, so that clients always have a path to
+      // the file system.
+      const clientSecurityGroup = new aws.ec2.SecurityGroup(
+        `${name}-client`,
+        { vpcId, region, tags: args.tags },
+        { parent: this },
+      );
+      new aws.vpc.SecurityGroupIngressRule(
+        `${name}-nfs`,
+        {
+          securityGroupId,
+          referencedSecurityGroupId: clientSecurityGroup.id,
+          ipProtocol: "tcp",
+          fromPort: nfsPort,
+          toPort: nfsPort,
+          region,
+        },
+        { parent: this },
+      );
+      new aws.vpc.SecurityGroupEgressRule(
+        `${name}-client-nfs`,
+        {
+          securityGroupId: clientSecurityGroup.id,
+          referencedSecurityGroupId: securityGroupId,
+          ipProtocol: "tcp",
+          fromPort: nfsPort,
+          toPort: nfsPort,
+          region,
+        },
+        { parent: this },
+      );
+      this.clientSecurityGroup = clientSecurityGroup;
+      securityGroupIds = [securityGroupId];
+    }
+
+    // A file system has at most one mount target per availability zone.
+    const availabilityZonesChecked = pulumi
+      .all(subnets.map((subnet) => subnet.availabilityZone))
+      .apply((zones) => {
+        const duplicate = zones.find((zone, i) => zones.indexOf(zone) !== i);
+        if (duplicate !== undefined) {
+          throw new Error(
+            `[subnetIds] must be in distinct availability zones, but several are in ${duplicate}`,
+          );
+        }
+        return true;
+      });
+    this.mountTargets = subnetIds.map(
+      (subnetId, i) =>
+        new aws.efs.MountTarget(
+          `${name}-${i}`,
+          {
+            fileSystemId: fileSystem.id,
+            subnetId: pulumi.all([subnetId, availabilityZonesChecked]).apply(([id]) => id),
+            securityGroups: securityGroupIds,
+            region,
+          },
+          { parent: this },
+        ),
+    );
+
+    this.registerOutputs({
+      fileSystem: this.fileSystem,
+      mountTargets: this.mountTargets,
+      securityGroup: this.securityGroup,
+      clientSecurityGroup: this.clientSecurityGroup,
+    });
+  }
+}
@@ -14,19 +14,45 @@
 
 import * as pulumi from "@pulumi/pulumi";
 import { readFileSync } from "fs";
-import { Repository } from "./ecr";
+import { Vpc } from "./ec2";
+import { Registry, Repository } from "./ecr";
+import { FileSystem } from "./efs";
 import { construct, functions } from "./resources";
 import { resourceToConstructResult } from "./utils";
 
 class Provider implements pulumi.provider.Provider {
   constructor(readonly version: string, readonly schema: string) {
     // Register any resources that can come back as resource references that need to be rehydrated.
+    pulumi.runtime.registerResourceModule("awsx", "ec2", {
+      version: this.version,
+      construct: (name, type, urn) => {
+        switch (type) {
+          case "awsx:ec2:Vpc":
+            return new Vpc(name, <any>undefined, { urn });
+          default:
+            throw new Error(`unknown resource type ${type}`);
+        }
+      },
+    });
     pulumi.runtime.registerResourceModule("awsx", "ecr", {
       version: this.version,
       construct: (name, type, urn) => {
         switch (type) {
           case "awsx:ecr:Repository":
             return new Repository(name, <any>undefined, { urn });
+          case "awsx:ecr:Registry":
+            return new Registry(name, <any>undefined, { urn });
+          default:
+            throw new Error(`unknown resource type ${type}`);
+        }
+      },
+    });
+    pulumi.runtime.registerResourceModule("awsx", "efs", {
+      version: this.version,
+      construct: (name, type, urn) => {
+        switch (type) {
+          case "awsx:efs:FileSystem":
+            return new FileSystem(name, <any>undefined, { urn });
           default:
             throw new Error(`unknown resource type ${type}`);
         }
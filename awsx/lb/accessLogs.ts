@@ -0,0 +1,120 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import * as aws from "@pulumi/aws";
+import * as pulumi from "@pulumi/pulumi";
+import { requiredBucket } from "../s3/bucket";
+import * as schema from "../schema-types";
+
+// Accounts delivering the access logs of Elastic Load Balancing in the regions available before
+// August 2022. Newer regions deliver logs with the log delivery service principal instead, see
+// https://docs.aws.amazon.com/elasticloadbalancing/latest/application/enable-access-logging.html
+const elbAccountIds: Record<string, string> = {
+  "us-east-1": "127311923021",
+  "us-east-2": "033677994240",
+  "us-west-1": "027434742980",
+  "us-west-2": "797873946194",
+  "af-south-1": "098369216593",
+  "ap-east-1": "754344448648",
+  "ap-southeast-3": "589379963580",
+  "ap-south-1": "718504428378",
+  "ap-northeast-3": "383597477331",
+  "ap-northeast-2": "600734575887",
+  "ap-southeast-1": "114774131450",
+  "ap-southeast-2": "783225319266",
+  "ap-northeast-1": "582318560864",
+  "ca-central-1": "985666609251",
+  "eu-central-1": "054676820928",
+  "eu-west-1": "156460612806",
+  "eu-west-2": "652711504416",
+  "eu-south-1": "635631232127",
+  "eu-west-3": "009996457667",
+  "eu-north-1": "897822967062",
+  "me-south-1": "076674570225",
+  "sa-east-1": "507241528517",
+  "us-gov-west-1": "048591011584",
+  "us-gov-east-1": "190560391635",
+  "cn-north-1": "638102146993",
+  "cn-northwest-1": "037604701340",
+};
+
+/**
+ * Builds the statement allowing Elastic Load Balancing to deliver access logs to the bucket in
+ * the given region.
+ * @internal
+ */
+export function accessLogsStatement(
+  region: string,
+  partition: string,
+  bucketArn: string,
+): aws.types.input.iam.PolicyStatement {
+  const accountId = elbAccountIds[region];
+  return {
+    Effect: "Allow",
+    Principal:
+      accountId !== undefined
+        ? { AWS: `arn:${partition}:iam::${accountId}:root` }
+        : { Service: "logdelivery.elasticloadbalancing.amazonaws.com" },
+    Action: "s3:PutObject",
+    Resource: `${bucketArn}/*`,
+  };
+}
+
+/**
+ * Creates the access logs bucket of a load balancer with the policy allowing log delivery.
+ * Existing buckets are used as is.
+ * @internal
+ */
+export function accessLogsBucket(
+  name: string,
+  accessLogs: schema.AccessLogsInputs,
+  region: pulumi.Input<string>,
+  parent: pulumi.Resource,
+): {
+  bucket?: aws.s3.Bucket;
+  policy?: aws.s3.BucketPolicy;
+  accessLogs: aws.types.input.lb.LoadBalancerAccessLogs;
+} {
+  const { bucket, bucketId } = requiredBucket(
+    name,
+    { existing: accessLogs.existing, args: accessLogs.args },
+    { region },
+    { parent },
+  );
+  const loadBalancerAccessLogs = {
+    bucket: bucketId.name,
+    prefix: accessLogs.prefix,
+    enabled: true,
+  };
+  if (bucket === undefined) {
+    return { accessLogs: loadBalancerAccessLogs };
+  }
+
+  const partition = aws.getPartitionOutput({}, { parent }).partition;
+  const policy = new aws.s3.BucketPolicy(
+    name,
+    {
+      bucket: bucket.bucket,
+      policy: pulumi.all([region, partition, bucket.arn]).apply(([region, partition, arn]) =>
+        JSON.stringify({
+          Version: "2012-10-17",
+          Statement: [accessLogsStatement(region, partition, arn)],
+        }),
+      ),
+      region,
+    },
+    { parent },
+  );
+  return { bucket, policy, accessLogs: loadBalancerAccessLogs };
+}
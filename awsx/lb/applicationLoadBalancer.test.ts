@@ -0,0 +1,306 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+import * as pulumi from "@pulumi/pulumi";
+
+import { domainValidationOptions, mockResources } from "../tests/mocks";
+import { ApplicationLoadBalancer } from "./applicationLoadBalancer";
+
+function promiseOf<T>(output: pulumi.Output<T>): Promise<T> {
+  return new Promise((resolve) => output.apply(resolve));
+}
+
+describe("ApplicationLoadBalancer", () => {
+  const { registeredResource } = mockResources({
+    state: (args) => {
+      switch (args.type) {
+        case "aws:acm/certificate:Certificate":
+          return { domainValidationOptions: domainValidationOptions(args.inputs.domainName) };
+        case "aws:route53/record:Record":
+          return { fqdn: args.inputs.name };
+        case "aws:lb/loadBalancer:LoadBalancer":
+          return { dnsName: `${args.name}.elb.amazonaws.com`, zoneId: "Z-ELB" };
+        case "aws:s3/bucket:Bucket":
+          return { bucket: args.name };
+        default:
+          return undefined;
+      }
+    },
+  });
+
+  it("serves HTTPS for the domain name", async () => {
+    const lb = new ApplicationLoadBalancer("web", {
+      subnetIds: ["subnet-a"],
+      https: { domainName: "app.example.com", zoneId: "Z123" },
+    });
+    expect(lb.certificate).toBeDefined();
+    expect(await promiseOf(pulumi.output(lb.dnsName!))).toBe("app.example.com");
+
+    const validation = await registeredResource("aws:route53/record:Record", "web-validation");
+    expect(validation.inputs).toMatchObject({
+      zoneId: "Z123",
+      name: "_validation.app.example.com",
+      type: "CNAME",
+      records: ["_validation.acm-validations.aws"],
+    });
+
+    const https = await registeredResource("aws:lb/listener:Listener", "web-https");
+    expect(https.inputs).toMatchObject({
+      port: 443,
+      protocol: "HTTPS",
+      certificateArn: "arn:web",
+      sslPolicy: "ELBSecurityPolicy-TLS13-1-2-2021-06",
+      defaultActions: [{ type: "forward", targetGroupArn: "arn:web" }],
+    });
+
+    const http = await registeredResource("aws:lb/listener:Listener", "web-http");
+    expect(http.inputs).toMatchObject({
+      port: 80,
+      protocol: "HTTP",
+      defaultActions: [
+        {
+          type: "redirect",
+          redirect: { port: "443", protocol: "HTTPS", statusCode: "HTTP_301" },
+        },
+      ],
+    });
+
+    const alias = await registeredResource("aws:route53/record:Record", "web-alias");
+    expect(alias.inputs.aliases).toEqual([
+      { name: "web.elb.amazonaws.com", zoneId: "Z-ELB", evaluateTargetHealth: true },
+    ]);
+  });
+
+  it("creates the HTTPS listeners and rules in the region of the load balancer", async () => {
+    new ApplicationLoadBalancer("regional", {
+      subnetIds: ["subnet-a"],
+      region: "eu-west-1",
+      https: {
+        domainName: "eu.example.com",
+        zoneId: "Z123",
+        rules: [{ pathPatterns: ["/api/*"], action: { targetGroup: "default" } }],
+      },
+    });
+
+    const https = await registeredResource("aws:lb/listener:Listener", "regional-https");
+    expect(https.inputs.region).toBe("eu-west-1");
+    const http = await registeredResource("aws:lb/listener:Listener", "regional-http");
+    expect(http.inputs.region).toBe("eu-west-1");
+    const rule = await registeredResource("aws:lb/listenerRule:ListenerRule", "regional-0-0");
+    expect(rule.inputs.region).toBe("eu-west-1");
+  });
+
+  it("outputs the load balancer DNS name without https", async () => {
+    const lb = new ApplicationLoadBalancer("plain", { subnetIds: ["subnet-a"] });
+    expect(lb.certificate).toBeUndefined();
+    expect(await promiseOf(pulumi.output(lb.dnsName!))).toBe("plain.elb.amazonaws.com");
+  });
+
+  it("creates listener rules", async () => {
+    const lb = new ApplicationLoadBalancer("routed", {
+      subnetIds: ["subnet-a"],
+      listener: {
+        port: 80,
+        rules: [
+          {
+            priority: 10,
+            pathPatterns: ["/api/*"],
+            httpHeaders: { "X-Canary": ["true"] },
+            action: { targetGroup: "default" },
+          },
+          {
+            hostHeaders: ["old.example.com"],
+            queryStrings: { legacy: "1", format: "xml" },
+            action: { fixedResponse: { contentType: "text/plain", statusCode: "410" } },
+          },
+        ],
+      },
+    });
+    const listenerRules = await promiseOf(pulumi.output(lb.listenerRules!));
+    expect(listenerRules).toHaveLength(1);
+    expect(listenerRules[0]).toHaveLength(2);
+
+    const listener = await registeredResource("aws:lb/listener:Listener", "routed-0");
+    expect(listener.inputs.rules).toBeUndefined();
+
+    const forward = await registeredResource("aws:lb/listenerRule:ListenerRule", "routed-0-0");
+    expect(forward.inputs).toMatchObject({
+      listenerArn: "arn:routed-0",
+      priority: 10,
+      conditions: [
+        { pathPattern: { values: ["/api/*"] } },
+        { httpHeader: { httpHeaderName: "X-Canary", values: ["true"] } },
+      ],
+      actions: [{ type: "forward", targetGroupArn: "arn:routed" }],
+    });
+
+    const fixed = await registeredResource("aws:lb/listenerRule:ListenerRule", "routed-0-1");
+    expect(fixed.inputs).toMatchObject({
+      conditions: [
+        { hostHeader: { values: ["old.example.com"] } },
+        { queryStrings: [{ key: "legacy", value: "1" }] },
+        { queryStrings: [{ key: "format", value: "xml" }] },
+      ],
+      actions: [
+        { type: "fixed-response", fixedResponse: { contentType: "text/plain", statusCode: "410" } },
+      ],
+    });
+  });
+
+  it("rejects rules forwarding to unknown target groups", () => {
+    expect(
+      () =>
+        new ApplicationLoadBalancer("unknown", {
+          subnetIds: ["subnet-a"],
+          listener: { rules: [{ pathPatterns: ["/"], action: { targetGroup: "blue" } }] },
+        }),
+    ).toThrow('Unknown target group "blue". Valid target groups are: default');
+  });
+
+  it("creates the target groups in the region of the load balancer", async () => {
+    new ApplicationLoadBalancer("regional-groups", {
+      subnetIds: ["subnet-a"],
+      region: "eu-west-1",
+      targetGroups: { blue: { port: 8080 } },
+    });
+
+    const defaultGroup = await registeredResource(
+      "aws:lb/targetGroup:TargetGroup",
+      "regional-groups",
+    );
+    expect(defaultGroup.inputs.region).toBe("eu-west-1");
+    const blue = await registeredResource("aws:lb/targetGroup:TargetGroup", "regional-groups-blue");
+    expect(blue.inputs.region).toBe("eu-west-1");
+  });
+
+  it("forwards to named target groups by weight", async () => {
+    const lb = new ApplicationLoadBalancer("canary", {
+      subnetIds: ["subnet-a"],
+      targetGroups: { blue: { port: 8080 }, green: { port: 8080 } },
+      weightedForwards: {
+        rollout: { weights: { blue: 90, green: 10 }, stickinessDuration: 300 },
+      },
+      listener: {
+        targetGroup: "rollout",
+        rules: [{ pathPatterns: ["/beta/*"], action: { targetGroup: "green" } }],
+      },
+    });
+    expect(Object.keys(lb.targetGroups!)).toEqual(["blue", "green"]);
+
+    const green = await registeredResource("aws:lb/targetGroup:TargetGroup", "canary-green");
+    expect(green.inputs).toMatchObject({ vpcId: "vpc-123", targetType: "ip", port: 8080 });
+
+    const listener = await registeredResource("aws:lb/listener:Listener", "canary-0");
+    expect(listener.inputs.defaultActions).toEqual([
+      {
+        type: "forward",
+        forward: {
+          targetGroups: [
+            { arn: "arn:canary-blue", weight: 90 },
+            { arn: "arn:canary-green", weight: 10 },
+          ],
+          stickiness: { enabled: true, duration: 300 },
+        },
+      },
+    ]);
+
+    const rule = await registeredResource("aws:lb/listenerRule:ListenerRule", "canary-0-0");
+    expect(rule.inputs.actions).toEqual([{ type: "forward", targetGroupArn: "arn:canary-green" }]);
+  });
+
+  it("rejects overriding the default target group name", () => {
+    expect(
+      () =>
+        new ApplicationLoadBalancer("reserved", {
+          subnetIds: ["subnet-a"],
+          targetGroups: { default: { port: 8080 } },
+        }),
+    ).toThrow('The target group name "default" is reserved for the default target group');
+  });
+
+  it("creates an access logs bucket with the regional delivery policy", async () => {
+    const lb = new ApplicationLoadBalancer("logged", {
+      subnetIds: ["subnet-a"],
+      accessLogsBucket: { prefix: "alb" },
+    });
+    expect(lb.accessLogsBucket).toBeDefined();
+
+    const policy = await registeredResource(
+      "aws:s3/bucketPolicy:BucketPolicy",
+      "logged-access-logs",
+    );
+    expect(JSON.parse(policy.inputs.policy).Statement).toEqual([
+      {
+        Effect: "Allow",
+        Principal: { AWS: "arn:aws:iam::797873946194:root" },
+        Action: "s3:PutObject",
+        Resource: "arn:logged-access-logs/*",
+      },
+    ]);
+
+    const loadBalancer = await registeredResource("aws:lb/loadBalancer:LoadBalancer", "logged");
+    expect(loadBalancer.inputs.accessLogs).toEqual({
+      bucket: "logged-access-logs",
+      prefix: "alb",
+      enabled: true,
+    });
+  });
+
+  it("rejects access logs with an access logs bucket", () => {
+    expect(
+      () =>
+        new ApplicationLoadBalancer("logged-twice", {
+          subnetIds: ["subnet-a"],
+          accessLogs: { bucket: "logs", enabled: true },
+          accessLogsBucket: {},
+        }),
+    ).toThrow("Only one of [accessLogs] or [accessLogsBucket] can be specified");
+  });
+
+  it("associates a web ACL created from a preset", async () => {
+    const lb = new ApplicationLoadBalancer("protected", {
+      subnetIds: ["subnet-a"],
+      webAclPreset: "baseline-sql-database",
+    });
+    expect(lb.webAcl).toBeDefined();
+
+    const webAcl = await registeredResource("aws:wafv2/webAcl:WebAcl", "protected");
+    expect(webAcl.inputs.scope).toBe("REGIONAL");
+    expect(webAcl.inputs.rules.map((r: any) => r.name)).toEqual([
+      "AWSManagedRulesCommonRuleSet",
+      "AWSManagedRulesKnownBadInputsRuleSet",
+      "AWSManagedRulesAmazonIpReputationList",
+      "AWSManagedRulesSQLiRuleSet",
+    ]);
+
+    const association = await registeredResource(
+      "aws:wafv2/webAclAssociation:WebAclAssociation",
+      "protected",
+    );
+    expect(association.inputs).toMatchObject({
+      resourceArn: "arn:protected",
+      webAclArn: "arn:protected",
+    });
+  });
+
+  it("rejects https with listeners", () => {
+    expect(
+      () =>
+        new ApplicationLoadBalancer("invalid", {
+          subnetIds: ["subnet-a"],
+          https: { domainName: "app.example.com", zoneId: "Z123" },
+          listener: { port: 8080 },
+        }),
+    ).toThrow("Only one of [https] or [listener] and [listeners] can be specified");
+  });
+});
@@ -17,6 +17,14 @@ import * as pulumi from "@pulumi/pulumi";
 import { getDefaultVpc } from "../ec2";
 import * as schema from "../schema-types";
 import * as utils from "../utils";
+import { accessLogsBucket } from "./accessLogs";
+import { defaultSslPolicy, issueCertificate } from "./certificate";
+import {
+  createListenerRules,
+  forwardTargets,
+  listenerDefaultActions,
+} from "./listenerRules";
+import { associateWebAcl } from "./webAcl";
 
 export class ApplicationLoadBalancer extends schema.ApplicationLoadBalancer {
   constructor(
@@ -44,6 +52,12 @@ export class ApplicationLoadBalancer extends schema.ApplicationLoadBalancer {
       defaultSecurityGroup,
       listener,
       listeners,
+      https,
+      targetGroups,
+      weightedForwards,
+      accessLogsBucket: accessLogsBucketArgs,
+      webAclArn,
+      webAclPreset,
       /* tslint:disable */ //rest args will always be last so don't have trailing commas
       ...restArgs
       /* tslint:enable */
@@ -75,6 +89,12 @@ export class ApplicationLoadBalancer extends schema.ApplicationLoadBalancer {
     if (listener && listeners) {
       throw new Error("Only one of [listener] and [listeners] can be specified");
     }
+    if (https && (listener || listeners)) {
+      throw new Error("Only one of [https] or [listener] and [listeners] can be specified");
+    }
+    if (listener?.tls || listeners?.some((l) => l.tls)) {
+      throw new Error("Listener [tls] is only supported by network load balancers");
+    }
 
     if (!lbArgs.securityGroups && !defaultSecurityGroup?.skip) {
       if (defaultSecurityGroup?.args && defaultSecurityGroup.securityGroupId) {
@@ -121,9 +141,33 @@ export class ApplicationLoadBalancer extends schema.ApplicationLoadBalancer {
     // we have removed this from the input properties in the schema
     lbArgs.loadBalancerType = "application";
 
+    let accessLogsPolicy: aws.s3.BucketPolicy | undefined;
+    if (accessLogsBucketArgs) {
+      if (lbArgs.accessLogs !== undefined) {
+        throw new Error("Only one of [accessLogs] or [accessLogsBucket] can be specified");
+      }
+      const region = lbArgs.region ?? utils.getRegion(this);
+      const logs = accessLogsBucket(`${name}-access-logs`, accessLogsBucketArgs, region, this);
+      this.accessLogsBucket = logs.bucket;
+      accessLogsPolicy = logs.policy;
+      lbArgs.accessLogs = logs.accessLogs;
+    }
+
+    // Elastic Load Balancing checks that it can write to the access logs bucket, so the bucket
+    // policy has to exist before the load balancer.
     this.loadBalancer = new aws.lb.LoadBalancer(name, lbArgs, {
       parent: this,
+      dependsOn: accessLogsPolicy,
     });
+    this.webAcl = associateWebAcl(
+      name,
+      this.loadBalancer,
+      webAclArn,
+      webAclPreset,
+      lbArgs.region,
+      this,
+    );
+    this.dnsName = this.loadBalancer.dnsName;
 
     if (defaultTargetGroup !== undefined && defaultTargetGroupPort !== undefined) {
       throw new Error(
@@ -133,68 +177,202 @@ export class ApplicationLoadBalancer extends schema.ApplicationLoadBalancer {
 
     const defaultProtocol = getDefaultProtocol(args);
 
-    this.defaultTargetGroup = new aws.lb.TargetGroup(
+    const defaultTargetGroupResource = new aws.lb.TargetGroup(
       name,
       {
         vpcId: this.vpcId,
+        region: lbArgs.region,
         targetType: "ip",
         ...defaultProtocol,
         ...defaultTargetGroup,
       },
       { parent: this },
     );
+    this.defaultTargetGroup = defaultTargetGroupResource;
 
-    const defaultActions = [
-      {
-        type: "forward",
-        targetGroupArn: this.defaultTargetGroup.arn,
-      },
-    ];
+    if (targetGroups?.default !== undefined) {
+      throw new Error('The target group name "default" is reserved for the default target group');
+    }
+    const namedTargetGroups: Record<string, aws.lb.TargetGroup> = {};
+    for (const [key, targetGroupArgs] of Object.entries(targetGroups ?? {})) {
+      namedTargetGroups[key] = new aws.lb.TargetGroup(
+        `${name}-${key}`,
+        {
+          vpcId: this.vpcId,
+          region: lbArgs.region,
+          targetType: "ip",
+          ...defaultProtocol,
+          ...targetGroupArgs,
+        },
+        { parent: this },
+      );
+    }
+    this.targetGroups = namedTargetGroups;
+    const targets = forwardTargets(
+      { default: defaultTargetGroupResource, ...namedTargetGroups },
+      weightedForwards,
+    );
 
-    if (listener) {
-      this.listeners = [
+    let listenerResources: aws.lb.Listener[];
+    let rules: (schema.ListenerRuleInputs[] | undefined)[];
+    if (https) {
+      const httpsListeners = createHttpsListeners(
+        name,
+        https,
+        this.loadBalancer,
+        listenerDefaultActions(https, targets),
+        lbArgs.region,
+        this,
+      );
+      this.certificate = httpsListeners.certificate;
+      this.dnsName = httpsListeners.dnsName;
+      listenerResources = httpsListeners.listeners;
+      rules = [https.rules];
+    } else if (listener) {
+      const { rules: listenerRules, targetGroup: _, tls: __, ...listenerArgs } = listener;
+      listenerResources = [
         new aws.lb.Listener(
           `${name}-0`,
           {
-            defaultActions,
             ...defaultProtocol,
-            ...listener,
+            ...listenerArgs,
+            defaultActions: listenerDefaultActions(listener, targets),
             loadBalancerArn: this.loadBalancer.arn,
           },
           { parent: this },
         ),
       ];
+      rules = [listenerRules];
     } else if (listeners) {
-      this.listeners = listeners.map(
-        (args, i) =>
+      listenerResources = listeners.map(
+        ({ rules: _, targetGroup: __, tls: ___, ...listenerArgs }, i) =>
           // TODO: Check name compat with classic
           new aws.lb.Listener(
             `${name}-${i}`,
             {
-              defaultActions,
-              ...getListenerProtocol(args),
-              ...args,
+              ...getListenerProtocol(listenerArgs),
+              ...listenerArgs,
+              defaultActions: listenerDefaultActions(listeners[i], targets),
               loadBalancerArn: this.loadBalancer.arn,
             },
             { parent: this },
           ),
       );
+      rules = listeners.map((l) => l.rules);
     } else {
-      this.listeners = [
+      listenerResources = [
         new aws.lb.Listener(
           `${name}-0`,
           {
-            defaultActions,
+            defaultActions: listenerDefaultActions({}, targets),
             ...defaultProtocol,
             loadBalancerArn: this.loadBalancer.arn,
           },
           { parent: this },
         ),
       ];
+      rules = [];
     }
+    this.listeners = listenerResources;
+
+    this.listenerRules = listenerResources.map((l, i) =>
+      createListenerRules(`${name}-${i}`, l, rules[i], targets, lbArgs.region, this),
+    );
+
+    this.registerOutputs({
+      loadBalancer: this.loadBalancer,
+      defaultSecurityGroup: this.defaultSecurityGroup,
+      defaultTargetGroup: this.defaultTargetGroup,
+      targetGroups: this.targetGroups,
+      listeners: this.listeners,
+      listenerRules: this.listenerRules,
+      certificate: this.certificate,
+      accessLogsBucket: this.accessLogsBucket,
+      webAcl: this.webAcl,
+      dnsName: this.dnsName,
+      vpcId: this.vpcId,
+    });
   }
 }
 
+/**
+ * Issues a DNS-validated certificate for the domain name and serves it on port 443, redirecting
+ * HTTP on port 80 to HTTPS. The domain name is pointed to the load balancer with an alias record.
+ */
+function createHttpsListeners(
+  name: string,
+  https: schema.ApplicationLoadBalancerHttpsInputs,
+  loadBalancer: aws.lb.LoadBalancer,
+  defaultActions: pulumi.Input<pulumi.Input<aws.types.input.lb.ListenerDefaultAction>[]>,
+  region: pulumi.Input<string> | undefined,
+  parent: pulumi.Resource,
+): {
+  certificate: aws.acm.Certificate;
+  listeners: aws.lb.Listener[];
+  dnsName: pulumi.Output<string>;
+} {
+  const { certificate, validation } = issueCertificate(
+    name,
+    https.domainName,
+    https.zoneId,
+    region,
+    parent,
+  );
+
+  const httpsListener = new aws.lb.Listener(
+    `${name}-https`,
+    {
+      loadBalancerArn: loadBalancer.arn,
+      port: 443,
+      protocol: "HTTPS",
+      certificateArn: validation.certificateArn,
+      sslPolicy: https.sslPolicy ?? defaultSslPolicy,
+      defaultActions,
+      region,
+    },
+    { parent },
+  );
+  const httpListener = new aws.lb.Listener(
+    `${name}-http`,
+    {
+      loadBalancerArn: loadBalancer.arn,
+      port: 80,
+      protocol: "HTTP",
+      defaultActions: [
+        {
+          type: "redirect",
+          redirect: { port: "443", protocol: "HTTPS", statusCode: "HTTP_301" },
+        },
+      ],
+      region,
+    },
+    { parent },
+  );
+
+  const alias = new aws.route53.Record(
+    `${name}-alias`,
+    {
+      zoneId: https.zoneId,
+      name: https.domainName,
+      type: "A",
+      aliases: [
+        {
+          name: loadBalancer.dnsName,
+          zoneId: loadBalancer.zoneId,
+          evaluateTargetHealth: true,
+        },
+      ],
+    },
+    { parent },
+  );
+
+  return {
+    certificate,
+    listeners: [httpsListener, httpListener],
+    dnsName: alias.fqdn,
+  };
+}
+
 /** Get default port if there's just 1 listener */
 function getDefaultProtocol(
   args: Readonly<schema.ApplicationLoadBalancerArgs>,
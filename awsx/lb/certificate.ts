@@ -0,0 +1,63 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import * as aws from "@pulumi/aws";
+import * as pulumi from "@pulumi/pulumi";
+
+/**
+ * Security policy of TLS listeners, supporting TLS 1.2 and 1.3.
+ * @internal
+ */
+export const defaultSslPolicy = "ELBSecurityPolicy-TLS13-1-2-2021-06";
+
+/**
+ * Issues a certificate for the domain name, validated with a DNS record in the hosted zone.
+ * @internal
+ */
+export function issueCertificate(
+  name: string,
+  domainName: pulumi.Input<string>,
+  zoneId: pulumi.Input<string>,
+  region: pulumi.Input<string> | undefined,
+  parent: pulumi.Resource,
+): { certificate: aws.acm.Certificate; validation: aws.acm.CertificateValidation } {
+  const certificate = new aws.acm.Certificate(
+    name,
+    { domainName, validationMethod: "DNS", region },
+    { parent },
+  );
+  const validationOption = certificate.domainValidationOptions[0];
+  const validationRecord = new aws.route53.Record(
+    `${name}-validation`,
+    {
+      zoneId,
+      name: validationOption.resourceRecordName,
+      type: validationOption.resourceRecordType,
+      records: [validationOption.resourceRecordValue],
+      ttl: 60,
+      allowOverwrite: true,
+    },
+    { parent },
+  );
+  const validation = new aws.acm.CertificateValidation(
+    name,
+    {
+      certificateArn: certificate.arn,
+      validationRecordFqdns: [validationRecord.fqdn],
+      region,
+    },
+    { parent },
+  );
+  return { certificate, validation };
+}
@@ -0,0 +1,80 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+import * as pulumi from "@pulumi/pulumi";
+
+import { mockResources } from "../tests/mocks";
+import { GatewayLoadBalancer } from "./gatewayLoadBalancer";
+
+describe("GatewayLoadBalancer", () => {
+  const { registeredResource } = mockResources({
+    state: (args) =>
+      args.type === "aws:ec2/vpcEndpointService:VpcEndpointService"
+        ? { serviceName: `com.amazonaws.vpce.${args.name}` }
+        : undefined,
+    call: (args) =>
+      args.token === "aws:ec2/getSubnet:getSubnet"
+        ? { ...args.inputs, vpcId: args.inputs.id.startsWith("app") ? "vpc-app" : "vpc-fw" }
+        : undefined,
+  });
+
+  it("routes traffic through an endpoint per availability zone", async () => {
+    const gwlb = new GatewayLoadBalancer("fw", {
+      subnetIds: ["fw-a", "fw-b"],
+      endpoints: [
+        { subnetId: "app-public-a", routeTableIds: ["rtb-a"], destinationCidrBlock: "10.0.0.0/16" },
+        {
+          subnetId: "app-public-b",
+          routeTableIds: ["rtb-b", pulumi.output("rtb-igw")],
+          destinationCidrBlock: "10.0.0.0/16",
+        },
+      ],
+    });
+
+    const loadBalancer = await registeredResource("aws:lb/loadBalancer:LoadBalancer", "fw");
+    expect(loadBalancer.inputs).toMatchObject({
+      loadBalancerType: "gateway",
+      subnets: ["fw-a", "fw-b"],
+    });
+
+    const targetGroup = await registeredResource("aws:lb/targetGroup:TargetGroup", "fw");
+    expect(targetGroup.inputs).toMatchObject({ vpcId: "vpc-fw", protocol: "GENEVE", port: 6081 });
+
+    const endpoint = await registeredResource("aws:ec2/vpcEndpoint:VpcEndpoint", "fw-1");
+    expect(endpoint.inputs).toMatchObject({
+      vpcId: "vpc-app",
+      serviceName: "com.amazonaws.vpce.fw",
+      vpcEndpointType: "GatewayLoadBalancer",
+      subnetIds: ["app-public-b"],
+    });
+
+    const route = await registeredResource("aws:ec2/route:Route", "fw-1-1");
+    expect(route.inputs).toMatchObject({
+      routeTableId: "rtb-igw",
+      destinationCidrBlock: "10.0.0.0/16",
+      vpcEndpointId: "fw-1-id",
+    });
+
+    expect(gwlb.routes).toHaveLength(3);
+  });
+
+  it("requires the destination of the routes", () => {
+    expect(
+      () =>
+        new GatewayLoadBalancer("fw-default", {
+          subnetIds: ["fw-a"],
+          endpoints: [{ subnetId: "app-public-a", routeTableIds: ["rtb-a"] }],
+        }),
+    ).toThrow("[destinationCidrBlock] must be specified for endpoint 0 with [routeTableIds]");
+  });
+});
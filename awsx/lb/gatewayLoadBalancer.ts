@@ -0,0 +1,159 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import * as aws from "@pulumi/aws";
+import * as pulumi from "@pulumi/pulumi";
+import * as schema from "../schema-types";
+
+const genevePort = 6081;
+
+/**
+ * Provides a Gateway Load Balancer to insert third-party appliances, e.g. firewalls, into the
+ * traffic of a VPC. Creates the load balancer with a GENEVE target group, a VPC endpoint service
+ * and gateway load balancer endpoints, and routes the traffic of the given route tables through
+ * the endpoints.
+ */
+export class GatewayLoadBalancer extends schema.GatewayLoadBalancer {
+  constructor(
+    name: string,
+    args: schema.GatewayLoadBalancerArgs,
+    opts: pulumi.ComponentResourceOptions = {},
+  ) {
+    super(name, {}, opts);
+    if (opts.urn) {
+      return; // Rehydrating, skip construction
+    }
+
+    const {
+      subnetIds,
+      endpoints,
+      targetGroup,
+      allowedPrincipals,
+      enableCrossZoneLoadBalancing,
+      region,
+      tags,
+    } = args;
+
+    const vpcId = aws.ec2.getSubnetOutput(
+      { id: pulumi.output(subnetIds).apply((ids) => ids[0]), region },
+      { parent: this },
+    ).vpcId;
+
+    const loadBalancer = new aws.lb.LoadBalancer(
+      name,
+      {
+        loadBalancerType: "gateway",
+        subnets: subnetIds,
+        enableCrossZoneLoadBalancing,
+        region,
+        tags,
+      },
+      { parent: this },
+    );
+    this.loadBalancer = loadBalancer;
+
+    const geneveTargetGroup = new aws.lb.TargetGroup(
+      name,
+      {
+        vpcId,
+        protocol: "GENEVE",
+        port: genevePort,
+        region,
+        tags,
+        ...targetGroup,
+      },
+      { parent: this },
+    );
+    this.targetGroup = geneveTargetGroup;
+
+    this.listener = new aws.lb.Listener(
+      name,
+      {
+        loadBalancerArn: loadBalancer.arn,
+        defaultActions: [{ type: "forward", targetGroupArn: geneveTargetGroup.arn }],
+        region,
+        tags,
+      },
+      { parent: this },
+    );
+
+    const endpointService = new aws.ec2.VpcEndpointService(
+      name,
+      {
+        acceptanceRequired: false,
+        gatewayLoadBalancerArns: [loadBalancer.arn],
+        allowedPrincipals,
+        region,
+        tags,
+      },
+      { parent: this },
+    );
+    this.endpointService = endpointService;
+
+    const vpcEndpoints: aws.ec2.VpcEndpoint[] = [];
+    const routes: aws.ec2.Route[] = [];
+    (endpoints ?? []).forEach((endpoint, i) => {
+      const vpcEndpoint = new aws.ec2.VpcEndpoint(
+        `${name}-${i}`,
+        {
+          vpcId: aws.ec2.getSubnetOutput({ id: endpoint.subnetId, region }, { parent: this })
+            .vpcId,
+          serviceName: endpointService.serviceName,
+          vpcEndpointType: "GatewayLoadBalancer",
+          subnetIds: [endpoint.subnetId],
+          region,
+          tags,
+        },
+        { parent: this },
+      );
+      vpcEndpoints.push(vpcEndpoint);
+
+      if (endpoint.routeTableIds === undefined) {
+        return;
+      }
+      // Route tables usually already have a default route, e.g. to an internet gateway, which
+      // can't be replaced by a route through the endpoint.
+      if (endpoint.destinationCidrBlock === undefined) {
+        throw new Error(
+          `[destinationCidrBlock] must be specified for endpoint ${i} with [routeTableIds]`,
+        );
+      }
+      endpoint.routeTableIds.forEach((routeTableId, j) =>
+        routes.push(
+          new aws.ec2.Route(
+            `${name}-${i}-${j}`,
+            {
+              routeTableId,
+              destinationCidrBlock: endpoint.destinationCidrBlock!,
+              vpcEndpointId: vpcEndpoint.id,
+              region,
+            },
+            { parent: this },
+          ),
+        ),
+      );
+    });
+    this.endpoints = vpcEndpoints;
+    this.routes = routes;
+
+    this.registerOutputs({
+      loadBalancer: this.loadBalancer,
+      targetGroup: this.targetGroup,
+      listener: this.listener,
+      endpointService: this.endpointService,
+      endpoints: this.endpoints,
+      routes: this.routes,
+    });
+  }
+}
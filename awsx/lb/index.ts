@@ -13,5 +13,7 @@
 // limitations under the License.
 
 export * from "./applicationLoadBalancer";
+export * from "./gatewayLoadBalancer";
+export * from "./lambdaTarget";
 export * from "./networkLoadBalancer";
 export * from "./targetGroupAttachment";
@@ -0,0 +1,66 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import { mockResources } from "../tests/mocks";
+import { LambdaTarget } from "./lambdaTarget";
+
+describe("LambdaTarget", () => {
+  const { registeredResource } = mockResources();
+
+  it("forwards matching requests to the function", async () => {
+    new LambdaTarget("api", {
+      listenerArn: "arn:listener",
+      lambdaArn: "arn:function",
+      pathPatterns: ["/api/*"],
+      priority: 10,
+      multiValueHeadersEnabled: true,
+    });
+
+    const targetGroup = await registeredResource("aws:lb/targetGroup:TargetGroup", "api");
+    expect(targetGroup.inputs).toMatchObject({
+      targetType: "lambda",
+      lambdaMultiValueHeadersEnabled: true,
+    });
+
+    const permission = await registeredResource("aws:lambda/permission:Permission", "api");
+    expect(permission.inputs).toMatchObject({
+      function: "arn:function",
+      principal: "elasticloadbalancing.amazonaws.com",
+      sourceArn: "arn:api",
+    });
+
+    const attachment = await registeredResource(
+      "aws:lb/targetGroupAttachment:TargetGroupAttachment",
+      "api",
+    );
+    expect(attachment.inputs).toMatchObject({
+      targetGroupArn: "arn:api",
+      targetId: "arn:function",
+    });
+
+    const rule = await registeredResource("aws:lb/listenerRule:ListenerRule", "api");
+    expect(rule.inputs).toMatchObject({
+      listenerArn: "arn:listener",
+      priority: 10,
+      conditions: [{ pathPattern: { values: ["/api/*"] } }],
+      actions: [{ type: "forward", targetGroupArn: "arn:api" }],
+    });
+  });
+
+  it("requires a path or host pattern", () => {
+    expect(
+      () => new LambdaTarget("any", { listenerArn: "arn:listener", lambdaArn: "arn:function" }),
+    ).toThrow("At least one of [pathPatterns] or [hostHeaders] must be specified");
+  });
+});
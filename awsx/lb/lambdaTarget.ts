@@ -0,0 +1,106 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import * as aws from "@pulumi/aws";
+import * as pulumi from "@pulumi/pulumi";
+import * as schema from "../schema-types";
+import * as utils from "../utils";
+import { ruleConditions } from "./listenerRules";
+
+/**
+ * Forwards requests of an application load balancer listener matching a path or host pattern to
+ * a Lambda Function. Creates the target group, the permission allowing the load balancer to
+ * invoke the function, the attachment and the listener rule.
+ */
+export class LambdaTarget extends schema.LambdaTarget {
+  constructor(
+    name: string,
+    args: schema.LambdaTargetArgs,
+    opts: pulumi.ComponentResourceOptions = {},
+  ) {
+    super(name, {}, opts);
+
+    const {
+      listenerArn,
+      lambda,
+      lambdaArn,
+      pathPatterns,
+      hostHeaders,
+      priority,
+      healthCheck,
+      multiValueHeadersEnabled,
+      region,
+      tags,
+    } = args;
+    if (utils.countDefined([lambda, lambdaArn]) !== 1) {
+      throw new Error("Exactly 1 of [lambda] or [lambdaArn] must be provided");
+    }
+    if (pathPatterns === undefined && hostHeaders === undefined) {
+      throw new Error("At least one of [pathPatterns] or [hostHeaders] must be specified");
+    }
+
+    const targetGroup = new aws.lb.TargetGroup(
+      name,
+      {
+        targetType: "lambda",
+        lambdaMultiValueHeadersEnabled: multiValueHeadersEnabled,
+        healthCheck,
+        region,
+        tags,
+      },
+      { parent: this },
+    );
+    this.targetGroup = targetGroup;
+
+    const functionArn = lambda !== undefined ? pulumi.output(lambda).arn : lambdaArn!;
+    const lambdaPermission = new aws.lambda.Permission(
+      name,
+      {
+        action: "lambda:InvokeFunction",
+        function: functionArn,
+        principal: "elasticloadbalancing.amazonaws.com",
+        sourceArn: targetGroup.arn,
+        region,
+      },
+      { parent: this },
+    );
+    this.lambdaPermission = lambdaPermission;
+
+    this.targetGroupAttachment = new aws.lb.TargetGroupAttachment(
+      name,
+      { targetGroupArn: targetGroup.arn, targetId: functionArn, region },
+      { parent: this, dependsOn: [lambdaPermission] },
+    );
+
+    this.listenerRule = new aws.lb.ListenerRule(
+      name,
+      {
+        listenerArn,
+        priority,
+        conditions: ruleConditions({ pathPatterns, hostHeaders, action: {} }),
+        actions: [{ type: "forward", targetGroupArn: targetGroup.arn }],
+        region,
+        tags,
+      },
+      { parent: this },
+    );
+
+    this.registerOutputs({
+      targetGroup: this.targetGroup,
+      lambdaPermission: this.lambdaPermission,
+      targetGroupAttachment: this.targetGroupAttachment,
+      listenerRule: this.listenerRule,
+    });
+  }
+}
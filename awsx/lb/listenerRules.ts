@@ -0,0 +1,178 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import * as aws from "@pulumi/aws";
+import * as pulumi from "@pulumi/pulumi";
+import * as schema from "../schema-types";
+import * as utils from "../utils";
+
+/**
+ * Target groups and weighted forwards of a load balancer component, keyed by the name listeners
+ * and rules refer to them by.
+ * @internal
+ */
+export interface ForwardTargets {
+  targetGroups: Record<string, aws.lb.TargetGroup>;
+  weightedForwards: Record<string, schema.WeightedForwardInputs>;
+}
+
+/** @internal */
+export function forwardTargets(
+  targetGroups: Record<string, aws.lb.TargetGroup>,
+  weightedForwards: Record<string, schema.WeightedForwardInputs> | undefined,
+): ForwardTargets {
+  for (const name of Object.keys(weightedForwards ?? {})) {
+    if (name in targetGroups) {
+      throw new Error(`Weighted forward "${name}" has the same name as a target group`);
+    }
+  }
+  return { targetGroups, weightedForwards: weightedForwards ?? {} };
+}
+
+/**
+ * Resolves the default actions of a listener, forwarding to the default target group unless
+ * [defaultActions] or another [targetGroup] is specified.
+ * @internal
+ */
+export function listenerDefaultActions(
+  listener: Pick<schema.ListenerInputs, "defaultActions" | "targetGroup">,
+  targets: ForwardTargets,
+): pulumi.Input<pulumi.Input<aws.types.input.lb.ListenerDefaultAction>[]> {
+  if (listener.defaultActions !== undefined && listener.targetGroup !== undefined) {
+    throw new Error("Only one of [defaultActions] or [targetGroup] can be specified");
+  }
+  return listener.defaultActions ?? [forwardAction(targets, listener.targetGroup ?? "default")];
+}
+
+/**
+ * Creates the rules of a listener.
+ * @internal
+ */
+export function createListenerRules(
+  name: string,
+  listener: aws.lb.Listener,
+  rules: schema.ListenerRuleInputs[] | undefined,
+  targets: ForwardTargets,
+  region: pulumi.Input<string> | undefined,
+  parent: pulumi.Resource,
+): aws.lb.ListenerRule[] {
+  return (rules ?? []).map(
+    (rule, i) =>
+      new aws.lb.ListenerRule(
+        `${name}-${i}`,
+        {
+          listenerArn: listener.arn,
+          priority: rule.priority,
+          conditions: ruleConditions(rule),
+          actions: [ruleAction(rule.action, targets)],
+          region,
+        },
+        { parent },
+      ),
+  );
+}
+
+/** @internal */
+export function ruleConditions(
+  rule: schema.ListenerRuleInputs,
+): pulumi.Output<aws.types.input.lb.ListenerRuleCondition[]> {
+  const { hostHeaders, pathPatterns, httpHeaders, queryStrings } = rule;
+  if (
+    hostHeaders === undefined &&
+    pathPatterns === undefined &&
+    httpHeaders === undefined &&
+    queryStrings === undefined
+  ) {
+    throw new Error(
+      "At least one of [hostHeaders], [pathPatterns], [httpHeaders] or [queryStrings] must be specified for a listener rule",
+    );
+  }
+  return pulumi
+    .all([hostHeaders, pathPatterns, httpHeaders, queryStrings])
+    .apply(([hostHeaders, pathPatterns, httpHeaders, queryStrings]) => {
+      const conditions: aws.types.input.lb.ListenerRuleCondition[] = [];
+      if (hostHeaders !== undefined) {
+        conditions.push({ hostHeader: { values: hostHeaders } });
+      }
+      if (pathPatterns !== undefined) {
+        conditions.push({ pathPattern: { values: pathPatterns } });
+      }
+      for (const [httpHeaderName, values] of Object.entries(httpHeaders ?? {})) {
+        conditions.push({ httpHeader: { httpHeaderName, values } });
+      }
+      // The pairs of a single condition match if any of them matches, so each pair gets its own
+      // condition for requests to have to match all of them.
+      for (const [key, value] of Object.entries(queryStrings ?? {})) {
+        conditions.push({ queryStrings: [{ key, value }] });
+      }
+      return conditions;
+    });
+}
+
+/** @internal */
+export function ruleAction(
+  action: schema.ListenerRuleActionInputs,
+  targets: ForwardTargets,
+): aws.types.input.lb.ListenerRuleAction {
+  const { targetGroup, redirect, fixedResponse } = action;
+  if (utils.countDefined([targetGroup, redirect, fixedResponse]) !== 1) {
+    throw new Error(
+      "Exactly one of [targetGroup], [redirect] or [fixedResponse] must be specified for a listener rule action",
+    );
+  }
+  if (redirect !== undefined) {
+    return { type: "redirect", redirect };
+  }
+  if (fixedResponse !== undefined) {
+    return { type: "fixed-response", fixedResponse };
+  }
+  return forwardAction(targets, targetGroup!);
+}
+
+/**
+ * Builds the action forwarding to the target group or weighted forward with the given name.
+ * @internal
+ */
+export function forwardAction(
+  targets: ForwardTargets,
+  name: string,
+): aws.types.input.lb.ListenerRuleAction {
+  const weightedForward = targets.weightedForwards[name];
+  if (weightedForward === undefined) {
+    return { type: "forward", targetGroupArn: lookupTargetGroup(targets, name).arn };
+  }
+  const { weights, stickinessDuration } = weightedForward;
+  return {
+    type: "forward",
+    forward: {
+      targetGroups: Object.entries(weights).map(([targetGroup, weight]) => ({
+        arn: lookupTargetGroup(targets, targetGroup).arn,
+        weight,
+      })),
+      stickiness:
+        stickinessDuration === undefined
+          ? undefined
+          : { enabled: true, duration: stickinessDuration },
+    },
+  };
+}
+
+function lookupTargetGroup(targets: ForwardTargets, name: string): aws.lb.TargetGroup {
+  const targetGroup = targets.targetGroups[name];
+  if (targetGroup === undefined) {
+    const names = [...Object.keys(targets.targetGroups), ...Object.keys(targets.weightedForwards)];
+    throw new Error(`Unknown target group "${name}". Valid target groups are: ${names.join(", ")}`);
+  }
+  return targetGroup;
+}
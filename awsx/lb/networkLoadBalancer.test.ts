@@ -0,0 +1,94 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import { domainValidationOptions, mockResources } from "../tests/mocks";
+import { NetworkLoadBalancer } from "./networkLoadBalancer";
+
+describe("NetworkLoadBalancer", () => {
+  const { registeredResource } = mockResources({
+    state: (args) => {
+      switch (args.type) {
+        case "aws:acm/certificate:Certificate":
+          return { domainValidationOptions: domainValidationOptions(args.inputs.domainName) };
+        case "aws:route53/record:Record":
+          return { fqdn: args.inputs.name };
+        default:
+          return undefined;
+      }
+    },
+  });
+
+  it("terminates TLS with an issued certificate", async () => {
+    const lb = new NetworkLoadBalancer("grpc", {
+      subnetIds: ["subnet-a"],
+      listener: {
+        tls: { domainName: "grpc.example.com", zoneId: "Z123", alpnPolicy: "HTTP2Only" },
+      },
+    });
+
+    const validation = await registeredResource("aws:route53/record:Record", "grpc-0-validation");
+    expect(validation.inputs).toMatchObject({
+      zoneId: "Z123",
+      name: "_validation.grpc.example.com",
+    });
+
+    const listener = await registeredResource("aws:lb/listener:Listener", "grpc-0");
+    expect(listener.inputs).toMatchObject({
+      port: 443,
+      protocol: "TLS",
+      certificateArn: "arn:grpc-0",
+      sslPolicy: "ELBSecurityPolicy-TLS13-1-2-2021-06",
+      alpnPolicy: "HTTP2Only",
+    });
+    expect(listener.inputs.tls).toBeUndefined();
+    expect(lb.certificate).toBeDefined();
+  });
+
+  it("configures the target health state of target groups", async () => {
+    new NetworkLoadBalancer("draining", {
+      subnetIds: ["subnet-a"],
+      defaultTargetGroup: {
+        port: 8080,
+        targetHealthStates: [
+          { enableUnhealthyConnectionTermination: false, unhealthyDrainingInterval: 300 },
+        ],
+      },
+    });
+    const targetGroup = await registeredResource("aws:lb/targetGroup:TargetGroup", "draining");
+    expect(targetGroup.inputs.targetHealthStates).toEqual([
+      { enableUnhealthyConnectionTermination: false, unhealthyDrainingInterval: 300 },
+    ]);
+  });
+
+  it("requires exactly one TLS certificate source", () => {
+    expect(
+      () =>
+        new NetworkLoadBalancer("invalid", {
+          subnetIds: ["subnet-a"],
+          listeners: [{ tls: { alpnPolicy: "HTTP2Preferred" } }],
+        }),
+    ).toThrow("Exactly 1 of [tls.certificateArn] or [tls.domainName] must be provided");
+  });
+
+  it("issues a certificate for one listener only", () => {
+    const tls = { domainName: "grpc.example.com", zoneId: "Z123" };
+    expect(
+      () =>
+        new NetworkLoadBalancer("invalid-certificates", {
+          subnetIds: ["subnet-a"],
+          listeners: [{ tls }, { port: 8443, tls }],
+        }),
+    ).toThrow("Only one listener can specify [tls.domainName]");
+  });
+});
@@ -17,6 +17,8 @@ import * as pulumi from "@pulumi/pulumi";
 import { getDefaultVpc } from "../ec2";
 import * as schema from "../schema-types";
 import * as utils from "../utils";
+import { defaultSslPolicy, issueCertificate } from "./certificate";
+import { forwardTargets, listenerDefaultActions } from "./listenerRules";
 
 export class NetworkLoadBalancer extends schema.NetworkLoadBalancer {
   constructor(
@@ -43,6 +45,7 @@ export class NetworkLoadBalancer extends schema.NetworkLoadBalancer {
       defaultTargetGroupPort,
       listener,
       listeners,
+      targetGroups,
       /* tslint:disable */ //rest args will always be last so don't have trailing commas
       ...restArgs
       /* tslint:enable */
@@ -74,6 +77,9 @@ export class NetworkLoadBalancer extends schema.NetworkLoadBalancer {
     if (listener && listeners) {
       throw new Error("Only one of [listener] and [listeners] can be specified");
     }
+    if (listener?.rules || listeners?.some((l) => l.rules)) {
+      throw new Error("Listener [rules] are only supported by application load balancers");
+    }
 
     // this is a network loadbalancer so we set this explicitly
     // we have removed this from the input properties in the schema
@@ -93,59 +99,75 @@ export class NetworkLoadBalancer extends schema.NetworkLoadBalancer {
       );
     }
 
-    this.defaultTargetGroup = new aws.lb.TargetGroup(
+    const defaultTargetGroupResource = new aws.lb.TargetGroup(
       name,
       {
         vpcId: this.vpcId,
+        region: lbArgs.region,
         protocol: "TCP",
         port: defaultTargetGroupPort ?? 80,
         ...defaultTargetGroup,
       },
       { parent: this },
     );
+    this.defaultTargetGroup = defaultTargetGroupResource;
 
-    const defaultActions = [
-      {
-        type: "forward",
-        targetGroupArn: this.defaultTargetGroup.arn,
-      },
-    ];
+    if (targetGroups?.default !== undefined) {
+      throw new Error('The target group name "default" is reserved for the default target group');
+    }
+    const namedTargetGroups: Record<string, aws.lb.TargetGroup> = {};
+    for (const [key, targetGroupArgs] of Object.entries(targetGroups ?? {})) {
+      namedTargetGroups[key] = new aws.lb.TargetGroup(
+        `${name}-${key}`,
+        {
+          vpcId: this.vpcId,
+          region: lbArgs.region,
+          protocol: "TCP",
+          port: 80,
+          ...targetGroupArgs,
+        },
+        { parent: this },
+      );
+    }
+    this.targetGroups = namedTargetGroups;
+    const targets = forwardTargets(
+      { default: defaultTargetGroupResource, ...namedTargetGroups },
+      undefined,
+    );
 
-    if (listener) {
-      this.listeners = [
-        new aws.lb.Listener(
-          `${name}-0`,
-          {
-            defaultActions,
-            protocol: "TCP",
-            port: 80,
-            ...listener,
-            loadBalancerArn: this.loadBalancer.arn,
-          },
-          { parent: this },
-        ),
-      ];
-    } else if (listeners) {
-      this.listeners = listeners.map(
-        (args, i) =>
-          new aws.lb.Listener(
+    // Only one listener can issue a certificate, which is the [certificate] output.
+    const listenerSpecs = listeners ?? (listener ? [listener] : []);
+    if (listenerSpecs.filter((l) => l.tls?.domainName !== undefined).length > 1) {
+      throw new Error("Only one listener can specify [tls.domainName]");
+    }
+
+    if (listenerSpecs.length > 0) {
+      this.listeners = listenerSpecs.map(
+        ({ rules: _, targetGroup: __, tls: ___, ...listenerArgs }, i) => {
+          const tls = tlsListenerArgs(`${name}-${i}`, listenerSpecs[i], lbArgs.region, this);
+          if (tls.certificate !== undefined) {
+            this.certificate = tls.certificate;
+          }
+          return new aws.lb.Listener(
             `${name}-${i}`,
             {
-              defaultActions,
               protocol: "TCP",
               port: 80,
-              ...args,
+              ...tls.listenerArgs,
+              ...listenerArgs,
+              defaultActions: listenerDefaultActions(listenerSpecs[i], targets),
               loadBalancerArn: this.loadBalancer.arn,
             },
             { parent: this },
-          ),
+          );
+        },
       );
     } else {
       this.listeners = [
         new aws.lb.Listener(
           `${name}-0`,
           {
-            defaultActions,
+            defaultActions: listenerDefaultActions({}, targets),
             protocol: "TCP",
             port: 80,
             loadBalancerArn: this.loadBalancer.arn,
@@ -154,5 +176,56 @@ export class NetworkLoadBalancer extends schema.NetworkLoadBalancer {
         ),
       ];
     }
+
+    this.registerOutputs({
+      loadBalancer: this.loadBalancer,
+      defaultTargetGroup: this.defaultTargetGroup,
+      targetGroups: this.targetGroups,
+      listeners: this.listeners,
+      certificate: this.certificate,
+      vpcId: this.vpcId,
+    });
+  }
+}
+
+/**
+ * Terminates TLS on the listener with the given certificate, or a certificate issued for the
+ * domain name.
+ */
+function tlsListenerArgs(
+  name: string,
+  listener: schema.ListenerInputs,
+  region: pulumi.Input<string> | undefined,
+  parent: pulumi.Resource,
+): { listenerArgs: Partial<aws.lb.ListenerArgs>; certificate?: aws.acm.Certificate } {
+  const { tls } = listener;
+  if (tls === undefined) {
+    return { listenerArgs: {} };
+  }
+  if (listener.certificateArn !== undefined || listener.sslPolicy !== undefined) {
+    throw new Error("Only one of [tls] or [certificateArn] and [sslPolicy] can be specified");
+  }
+  if (utils.countDefined([tls.certificateArn, tls.domainName]) !== 1) {
+    throw new Error("Exactly 1 of [tls.certificateArn] or [tls.domainName] must be provided");
+  }
+  let certificateArn = tls.certificateArn;
+  let certificate: aws.acm.Certificate | undefined;
+  if (tls.domainName !== undefined) {
+    if (tls.zoneId === undefined) {
+      throw new Error("[tls.zoneId] must be provided with [tls.domainName]");
+    }
+    const issued = issueCertificate(name, tls.domainName, tls.zoneId, region, parent);
+    certificate = issued.certificate;
+    certificateArn = issued.validation.certificateArn;
   }
+  return {
+    listenerArgs: {
+      protocol: "TLS",
+      port: 443,
+      certificateArn,
+      sslPolicy: tls.sslPolicy ?? defaultSslPolicy,
+      alpnPolicy: tls.alpnPolicy,
+    },
+    certificate,
+  };
 }
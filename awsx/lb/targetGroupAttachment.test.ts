@@ -0,0 +1,91 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+import * as aws from "@pulumi/aws";
+
+import { mockResources } from "../tests/mocks";
+import { TargetGroupAttachment } from "./targetGroupAttachment";
+
+describe("TargetGroupAttachment", () => {
+  const { registeredResource } = mockResources();
+
+  it("attaches an IP address outside of the VPC", async () => {
+    new TargetGroupAttachment("ip", {
+      targetGroupArn: "arn:tg",
+      ipAddress: "10.1.0.5",
+      availabilityZone: "all",
+      port: 8080,
+    });
+    const attachment = await registeredResource(
+      "aws:lb/targetGroupAttachment:TargetGroupAttachment",
+      "ip",
+    );
+    expect(attachment.inputs).toMatchObject({
+      targetGroupArn: "arn:tg",
+      targetId: "10.1.0.5",
+      availabilityZone: "all",
+      port: 8080,
+    });
+  });
+
+  it("attaches the instances of an Auto Scaling Group", async () => {
+    const attachment = new TargetGroupAttachment("asg", {
+      targetGroupArn: "arn:tg",
+      autoScalingGroupName: "workers",
+    });
+    expect(attachment.targetGroupAttachment).toBeUndefined();
+    expect(attachment.autoScalingAttachment).toBeDefined();
+
+    const asgAttachment = await registeredResource("aws:autoscaling/attachment:Attachment", "asg");
+    expect(asgAttachment.inputs).toMatchObject({
+      autoscalingGroupName: "workers",
+      lbTargetGroupArn: "arn:tg",
+    });
+  });
+
+  it("chains a network load balancer to an application load balancer", async () => {
+    const alb = new aws.lb.LoadBalancer("alb", { loadBalancerType: "application" });
+    new TargetGroupAttachment("chain", {
+      targetGroupArn: "arn:nlb-tg",
+      loadBalancer: alb,
+      port: 443,
+    });
+    const attachment = await registeredResource(
+      "aws:lb/targetGroupAttachment:TargetGroupAttachment",
+      "chain",
+    );
+    expect(attachment.inputs).toMatchObject({ targetId: "arn:alb", port: 443 });
+  });
+
+  it("requires exactly one target", () => {
+    expect(
+      () =>
+        new TargetGroupAttachment("both", {
+          targetGroupArn: "arn:tg",
+          ipAddress: "10.1.0.5",
+          instanceId: "i-123",
+        }),
+    ).toThrow("Exactly 1 of [instance], [instanceId], [lambda], [lambdaArn], [ipAddress]");
+  });
+
+  it("rejects an availability zone without an IP address", () => {
+    expect(
+      () =>
+        new TargetGroupAttachment("az", {
+          targetGroupArn: "arn:tg",
+          instanceId: "i-123",
+          availabilityZone: "all",
+        }),
+    ).toThrow("[availabilityZone] can only be specified with [ipAddress]");
+  });
+});
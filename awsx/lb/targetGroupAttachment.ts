@@ -27,9 +27,32 @@ export class TargetGroupAttachment extends schema.TargetGroupAttachment {
     if (utils.countDefined([args.targetGroup, args.targetGroupArn]) !== 1) {
       throw new Error("Exactly 1 of [targetGroup] or [targetGroupArn] must be provided");
     }
-    if (utils.countDefined([args.instance, args.instanceId, args.lambda, args.lambdaArn]) !== 1) {
+    if (
+      utils.countDefined([
+        args.instance,
+        args.instanceId,
+        args.lambda,
+        args.lambdaArn,
+        args.ipAddress,
+        args.autoScalingGroup,
+        args.autoScalingGroupName,
+        args.loadBalancer,
+        args.loadBalancerArn,
+      ]) !== 1
+    ) {
       throw new Error(
-        "Exactly 1 of [instance], [instanceId], [lambda] or [lambdaArn] must be provided.",
+        "Exactly 1 of [instance], [instanceId], [lambda], [lambdaArn], [ipAddress], [autoScalingGroup], [autoScalingGroupName], [loadBalancer] or [loadBalancerArn] must be provided.",
+      );
+    }
+    if (args.availabilityZone !== undefined && args.ipAddress === undefined) {
+      throw new Error("[availabilityZone] can only be specified with [ipAddress]");
+    }
+    if (
+      args.port !== undefined &&
+      (args.autoScalingGroup !== undefined || args.autoScalingGroupName !== undefined)
+    ) {
+      throw new Error(
+        "[port] can't be specified with [autoScalingGroup] or [autoScalingGroupName]",
       );
     }
 
@@ -54,68 +77,134 @@ export class TargetGroupAttachment extends schema.TargetGroupAttachment {
       throw new Error("Unreachable");
     })();
 
-    const { instance, instanceId, lambda, lambdaArn } = args;
-
-    const attachmentArgs = (() => {
-      if (instance) {
-        const instanceOutputs = pulumi.output(instance);
-        return {
-          targetId: targetType.apply((t) =>
-            t === "instance" ? instanceOutputs.id : instanceOutputs.privateIp,
-          ),
-          availabilityZone: instanceOutputs.availabilityZone,
-        };
-      }
-      if (instanceId) {
-        const instanceOutputs = aws.ec2.getInstanceOutput(
-          {
-            instanceId,
-          },
-          { parent: this },
-        );
-        return {
-          targetId: targetType.apply((t) =>
-            t === "instance" ? instanceOutputs.id : instanceOutputs.privateIp,
-          ),
-          availabilityZone: instanceOutputs.availabilityZone,
-        };
-      }
-      if (lambda) {
-        return {
-          targetId: pulumi.output(lambda).arn,
-        };
-      }
-      if (lambdaArn) {
-        return {
-          targetId: lambdaArn,
-        };
-      }
-      throw new Error("Unreachable condition");
-    })();
-
-    if (lambda || lambdaArn) {
-      this.lambdaPermission = new aws.lambda.Permission(
+    if (args.autoScalingGroup || args.autoScalingGroupName) {
+      this.autoScalingAttachment = new aws.autoscaling.Attachment(
         name,
         {
-          action: "lambda:InvokeFunction",
-          function: lambda ?? lambdaArn!,
-          principal: "elasticloadbalancing.amazonaws.com",
-          sourceArn: targetGroupArn,
+          autoscalingGroupName:
+            args.autoScalingGroupName ?? pulumi.output(args.autoScalingGroup!).name,
+          lbTargetGroupArn: targetGroupArn,
         },
         { parent: this },
       );
+    } else {
+      const attachment = attachTarget(name, args, targetGroupArn, targetType, this);
+      this.targetGroupAttachment = attachment.targetGroupAttachment;
+      this.lambdaPermission = attachment.lambdaPermission;
     }
 
-    this.targetGroupAttachment = new aws.lb.TargetGroupAttachment(
+    this.registerOutputs({
+      targetGroupAttachment: this.targetGroupAttachment,
+      autoScalingAttachment: this.autoScalingAttachment,
+      lambdaPermission: this.lambdaPermission,
+    });
+  }
+}
+
+/**
+ * Attaches a target other than an Auto Scaling Group, which are attached to the Target Group
+ * directly by the Auto Scaling Attachment.
+ */
+function attachTarget(
+  name: string,
+  args: schema.TargetGroupAttachmentArgs,
+  targetGroupArn: pulumi.Input<string>,
+  targetType: pulumi.Output<string>,
+  parent: pulumi.Resource,
+): {
+  targetGroupAttachment: aws.lb.TargetGroupAttachment;
+  lambdaPermission?: aws.lambda.Permission;
+} {
+  const {
+    instance,
+    instanceId,
+    lambda,
+    lambdaArn,
+    ipAddress,
+    availabilityZone,
+    loadBalancer,
+    loadBalancerArn,
+    port,
+  } = args;
+
+  const attachmentArgs = (() => {
+    if (instance) {
+      const instanceOutputs = pulumi.output(instance);
+      return {
+        targetId: targetType.apply((t) =>
+          t === "instance" ? instanceOutputs.id : instanceOutputs.privateIp,
+        ),
+        availabilityZone: instanceOutputs.availabilityZone,
+      };
+    }
+    if (instanceId) {
+      const instanceOutputs = aws.ec2.getInstanceOutput(
+        {
+          instanceId,
+        },
+        { parent },
+      );
+      return {
+        targetId: targetType.apply((t) =>
+          t === "instance" ? instanceOutputs.id : instanceOutputs.privateIp,
+        ),
+        availabilityZone: instanceOutputs.availabilityZone,
+      };
+    }
+    if (lambda) {
+      return {
+        targetId: pulumi.output(lambda).arn,
+      };
+    }
+    if (lambdaArn) {
+      return {
+        targetId: lambdaArn,
+      };
+    }
+    if (ipAddress) {
+      return {
+        targetId: ipAddress,
+        availabilityZone,
+      };
+    }
+    if (loadBalancer) {
+      return {
+        targetId: pulumi.output(loadBalancer).arn,
+      };
+    }
+    if (loadBalancerArn) {
+      return {
+        targetId: loadBalancerArn,
+      };
+    }
+    throw new Error("Unreachable condition");
+  })();
+
+  let lambdaPermission: aws.lambda.Permission | undefined;
+  if (lambda || lambdaArn) {
+    lambdaPermission = new aws.lambda.Permission(
       name,
       {
-        ...attachmentArgs,
-        targetGroupArn: targetGroupArn,
-      },
-      {
-        parent: this,
-        dependsOn: this.lambdaPermission ? [this.lambdaPermission] : [],
+        action: "lambda:InvokeFunction",
+        function: lambda ?? lambdaArn!,
+        principal: "elasticloadbalancing.amazonaws.com",
+        sourceArn: targetGroupArn,
       },
+      { parent },
     );
   }
+
+  const targetGroupAttachment = new aws.lb.TargetGroupAttachment(
+    name,
+    {
+      ...attachmentArgs,
+      targetGroupArn: targetGroupArn,
+      port,
+    },
+    {
+      parent,
+      dependsOn: lambdaPermission ? [lambdaPermission] : [],
+    },
+  );
+  return { targetGroupAttachment, lambdaPermission };
 }
@@ -0,0 +1,92 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import * as aws from "@pulumi/aws";
+import * as pulumi from "@pulumi/pulumi";
+import * as schema from "../schema-types";
+
+const baselineRuleGroups = [
+  "AWSManagedRulesCommonRuleSet",
+  "AWSManagedRulesKnownBadInputsRuleSet",
+  "AWSManagedRulesAmazonIpReputationList",
+];
+
+/**
+ * AWS managed rule groups of each web ACL preset, in order of evaluation.
+ * @internal
+ */
+export const webAclPresetRuleGroups: Record<schema.WebAclPresetInputs, string[]> = {
+  baseline: baselineRuleGroups,
+  "baseline-sql-database": [...baselineRuleGroups, "AWSManagedRulesSQLiRuleSet"],
+  "baseline-linux": [
+    ...baselineRuleGroups,
+    "AWSManagedRulesLinuxRuleSet",
+    "AWSManagedRulesUnixRuleSet",
+  ],
+};
+
+/**
+ * Associates a web ACL with the load balancer, creating the web ACL from a preset if no ARN is
+ * given.
+ * @internal
+ */
+export function associateWebAcl(
+  name: string,
+  loadBalancer: aws.lb.LoadBalancer,
+  webAclArn: pulumi.Input<string> | undefined,
+  webAclPreset: schema.WebAclPresetInputs | undefined,
+  region: pulumi.Input<string> | undefined,
+  parent: pulumi.Resource,
+): aws.wafv2.WebAcl | undefined {
+  if (webAclArn !== undefined && webAclPreset !== undefined) {
+    throw new Error("Only one of [webAclArn] or [webAclPreset] can be specified");
+  }
+  let webAcl: aws.wafv2.WebAcl | undefined;
+  if (webAclPreset !== undefined) {
+    webAcl = new aws.wafv2.WebAcl(
+      name,
+      {
+        scope: "REGIONAL",
+        defaultAction: { allow: {} },
+        rules: webAclPresetRuleGroups[webAclPreset].map((ruleGroup, priority) => ({
+          name: ruleGroup,
+          priority,
+          overrideAction: { none: {} },
+          statement: { managedRuleGroupStatement: { vendorName: "AWS", name: ruleGroup } },
+          visibilityConfig: {
+            cloudwatchMetricsEnabled: true,
+            metricName: ruleGroup,
+            sampledRequestsEnabled: true,
+          },
+        })),
+        visibilityConfig: {
+          cloudwatchMetricsEnabled: true,
+          metricName: name,
+          sampledRequestsEnabled: true,
+        },
+        region,
+      },
+      { parent },
+    );
+    webAclArn = webAcl.arn;
+  }
+  if (webAclArn !== undefined) {
+    new aws.wafv2.WebAclAssociation(
+      name,
+      { resourceArn: loadBalancer.arn, webAclArn, region },
+      { parent },
+    );
+  }
+  return webAcl;
+}
@@ -15,8 +15,9 @@
 import * as pulumi from "@pulumi/pulumi";
 import { Trail } from "./cloudtrail";
 import * as ec2 from "./ec2";
-import { Image, RegistryImage, Repository } from "./ecr";
+import { Image, ImageCopy, Registry, RegistryImage, Repository } from "./ecr";
 import * as ecs from "./ecs";
+import * as efs from "./efs";
 import * as lb from "./lb";
 import * as schemaTypes from "./schema-types";
 
@@ -26,14 +27,19 @@ const resources: schemaTypes.ResourceConstructor = {
   "awsx:ecs:EC2Service": (...args) => new ecs.EC2Service(...args),
   "awsx:ecs:EC2TaskDefinition": (...args) => new ecs.EC2TaskDefinition(...args),
   "awsx:ecs:FargateTaskDefinition": (...args) => new ecs.FargateTaskDefinition(...args),
+  "awsx:efs:FileSystem": (...args) => new efs.FileSystem(...args),
   "awsx:lb:ApplicationLoadBalancer": (...args) => new lb.ApplicationLoadBalancer(...args),
   "awsx:lb:NetworkLoadBalancer": (...args) => new lb.NetworkLoadBalancer(...args),
   "awsx:lb:TargetGroupAttachment": (...args) => new lb.TargetGroupAttachment(...args),
+  "awsx:lb:GatewayLoadBalancer": (...args) => new lb.GatewayLoadBalancer(...args),
+  "awsx:lb:LambdaTarget": (...args) => new lb.LambdaTarget(...args),
   "awsx:ec2:Vpc": (...args) => new ec2.Vpc(...args),
   "awsx:ec2:DefaultVpc": (...args) => new ec2.DefaultVpc(...args),
   "awsx:ecr:Repository": (...args) => new Repository(...args),
   "awsx:ecr:Image": (...args) => new Image(...args),
+  "awsx:ecr:ImageCopy": (...args) => new ImageCopy(...args),
   "awsx:ecr:RegistryImage": (...args) => new RegistryImage(...args),
+  "awsx:ecr:Registry": (...args) => new Registry(...args),
 };
 
 export function construct(
@@ -100,3 +100,55 @@ export function defaultRoleWithPolicies(
   );
   return { role, policies, roleArn: role.arn };
 }
+
+/** @internal */
+export function grantRolePolicies(
+  name: string,
+  role: aws.iam.Role | undefined,
+  grants: {
+    /** Names of AWS managed policies, e.g. `CloudWatchAgentServerPolicy`. */
+    managedPolicies?: string[];
+    statements?: pulumi.Input<aws.types.input.iam.PolicyStatement>[];
+  },
+  opts: ResourceOptions,
+): {
+  rolePolicy?: aws.iam.RolePolicy;
+  policies?: aws.iam.RolePolicyAttachment[];
+} {
+  // Grants are only added to roles created by the component. Roles passed in by ARN are owned
+  // by the user and must already carry the required permissions.
+  if (role === undefined) {
+    return {};
+  }
+  let policies: aws.iam.RolePolicyAttachment[] | undefined;
+  if (grants.managedPolicies !== undefined && grants.managedPolicies.length > 0) {
+    const partition = aws.getPartitionOutput({}, opts).partition;
+    policies = grants.managedPolicies.map(
+      (policyName) =>
+        new aws.iam.RolePolicyAttachment(
+          `${name}-${utils.sha1hash(policyName)}`,
+          {
+            role: role.name,
+            policyArn: pulumi.interpolate`arn:${partition}:iam::aws:policy/${policyName}`,
+          },
+          opts,
+        ),
+    );
+  }
+  if (grants.statements === undefined || grants.statements.length === 0) {
+    return { policies };
+  }
+  const document: aws.types.input.iam.PolicyDocument = {
+    Version: "2012-10-17",
+    Statement: grants.statements,
+  };
+  const rolePolicy = new aws.iam.RolePolicy(
+    name,
+    {
+      role: role.name,
+      policy: pulumi.output(document).apply((d) => JSON.stringify(d)),
+    },
+    opts,
+  );
+  return { rolePolicy, policies };
+}
@@ -10,13 +10,18 @@ export type ResourceConstructor = {
     readonly "awsx:ec2:DefaultVpc": ConstructComponent<DefaultVpc>;
     readonly "awsx:ec2:Vpc": ConstructComponent<Vpc>;
     readonly "awsx:ecr:Image": ConstructComponent<Image>;
+    readonly "awsx:ecr:ImageCopy": ConstructComponent<ImageCopy>;
+    readonly "awsx:ecr:Registry": ConstructComponent<Registry>;
     readonly "awsx:ecr:RegistryImage": ConstructComponent<RegistryImage>;
     readonly "awsx:ecr:Repository": ConstructComponent<Repository>;
     readonly "awsx:ecs:EC2Service": ConstructComponent<EC2Service>;
     readonly "awsx:ecs:EC2TaskDefinition": ConstructComponent<EC2TaskDefinition>;
     readonly "awsx:ecs:FargateService": ConstructComponent<FargateService>;
     readonly "awsx:ecs:FargateTaskDefinition": ConstructComponent<FargateTaskDefinition>;
+    readonly "awsx:efs:FileSystem": ConstructComponent<FileSystem>;
     readonly "awsx:lb:ApplicationLoadBalancer": ConstructComponent<ApplicationLoadBalancer>;
+    readonly "awsx:lb:GatewayLoadBalancer": ConstructComponent<GatewayLoadBalancer>;
+    readonly "awsx:lb:LambdaTarget": ConstructComponent<LambdaTarget>;
     readonly "awsx:lb:NetworkLoadBalancer": ConstructComponent<NetworkLoadBalancer>;
     readonly "awsx:lb:TargetGroupAttachment": ConstructComponent<TargetGroupAttachment>;
 };
@@ -109,24 +114,69 @@ export interface VpcArgs {
     readonly vpcEndpointStrategy?: VpcEndpointStrategyInputs;
 }
 export abstract class Image<TData = any> extends (pulumi.ComponentResource)<TData> {
+    public attestationRefs?: string[] | pulumi.Output<string[]>;
+    public digest?: string | pulumi.Output<string>;
     public imageUri!: string | pulumi.Output<string>;
+    public platformDigests?: Record<string, string> | pulumi.Output<Record<string, string>>;
+    public scanFindings?: ScanFindingsSummaryOutputs | pulumi.Output<ScanFindingsSummaryOutputs>;
+    public signatureRefs?: string[] | pulumi.Output<string[]>;
+    public taggedUris!: string[] | pulumi.Output<string[]>;
+    public tags!: string[] | pulumi.Output<string[]>;
     constructor(name: string, args: pulumi.Inputs, opts: pulumi.ComponentResourceOptions = {}) {
-        super("awsx:ecr:Image", name, opts.urn ? { imageUri: undefined } : { name, args, opts }, opts);
+        super("awsx:ecr:Image", name, opts.urn ? { attestationRefs: undefined, digest: undefined, imageUri: undefined, platformDigests: undefined, scanFindings: undefined, signatureRefs: undefined, taggedUris: undefined, tags: undefined } : { name, args, opts }, opts);
     }
 }
 export interface ImageArgs {
     readonly args?: pulumi.Input<Record<string, pulumi.Input<string>>>;
+    readonly attestations?: pulumi.Input<ImageAttestationsInputs>;
     readonly builderVersion?: BuilderVersionInputs;
+    readonly cache?: pulumi.Input<BuildCacheInputs>;
     readonly cacheFrom?: pulumi.Input<pulumi.Input<string>[]>;
+    readonly checkImmutableTags?: boolean;
     readonly context?: pulumi.Input<string>;
     readonly dockerfile?: pulumi.Input<string>;
     readonly imageName?: pulumi.Input<string>;
     readonly imageTag?: pulumi.Input<string>;
     readonly platform?: pulumi.Input<string>;
+    readonly platforms?: pulumi.Input<pulumi.Input<string>[]>;
     readonly registryId?: pulumi.Input<string>;
     readonly repositoryUrl: pulumi.Input<string>;
+    readonly scanGate?: pulumi.Input<ScanGateInputs>;
+    readonly secrets?: Record<string, BuildSecretInputs>;
+    readonly signing?: pulumi.Input<ImageSigningInputs>;
+    readonly ssh?: pulumi.Input<pulumi.Input<BuildSshInputs>[]>;
+    readonly tags?: pulumi.Input<pulumi.Input<string>[]>;
     readonly target?: pulumi.Input<string>;
 }
+export abstract class ImageCopy<TData = any> extends (pulumi.ComponentResource)<TData> {
+    public digest!: string | pulumi.Output<string>;
+    public imageUri!: string | pulumi.Output<string>;
+    constructor(name: string, args: pulumi.Inputs, opts: pulumi.ComponentResourceOptions = {}) {
+        super("awsx:ecr:ImageCopy", name, opts.urn ? { digest: undefined, imageUri: undefined } : { name, args, opts }, opts);
+    }
+}
+export interface ImageCopyArgs {
+    readonly repositoryUrl: pulumi.Input<string>;
+    readonly source: pulumi.Input<ImageCopySourceInputs>;
+    readonly tag?: pulumi.Input<string>;
+}
+export abstract class Registry<TData = any> extends (pulumi.ComponentResource)<TData> {
+    public pullThroughCacheRules?: Record<string, aws.ecr.PullThroughCacheRule> | pulumi.Output<Record<string, aws.ecr.PullThroughCacheRule>>;
+    public registryId!: string | pulumi.Output<string>;
+    public replicaRegistryUrls!: Record<string, string> | pulumi.Output<Record<string, string>>;
+    public replicatedRepositoryPrefixes!: string[] | pulumi.Output<string[]>;
+    public replicationConfiguration?: aws.ecr.ReplicationConfiguration | pulumi.Output<aws.ecr.ReplicationConfiguration>;
+    public scanningConfiguration?: aws.ecr.RegistryScanningConfiguration | pulumi.Output<aws.ecr.RegistryScanningConfiguration>;
+    constructor(name: string, args: pulumi.Inputs, opts: pulumi.ComponentResourceOptions = {}) {
+        super("awsx:ecr:Registry", name, opts.urn ? { pullThroughCacheRules: undefined, registryId: undefined, replicaRegistryUrls: undefined, replicatedRepositoryPrefixes: undefined, replicationConfiguration: undefined, scanningConfiguration: undefined } : { name, args, opts }, opts);
+    }
+}
+export interface RegistryArgs {
+    readonly pullThroughCacheRules?: Record<string, PullThroughCacheRuleInputs>;
+    readonly region?: pulumi.Input<string>;
+    readonly replication?: RegistryReplicationInputs;
+    readonly scanning?: RegistryScanningInputs;
+}
 export abstract class RegistryImage<TData = any> extends (pulumi.ComponentResource)<TData> {
     public image!: docker.RegistryImage | pulumi.Output<docker.RegistryImage>;
     constructor(name: string, args: pulumi.Inputs, opts: pulumi.ComponentResourceOptions = {}) {
@@ -143,13 +193,17 @@ export interface RegistryImageArgs {
 }
 export abstract class Repository<TData = any> extends (pulumi.ComponentResource)<TData> {
     public lifecyclePolicy?: aws.ecr.LifecyclePolicy | pulumi.Output<aws.ecr.LifecyclePolicy>;
+    public replicaUrls?: Record<string, string> | pulumi.Output<Record<string, string>>;
     public repository!: aws.ecr.Repository | pulumi.Output<aws.ecr.Repository>;
+    public repositoryPolicy?: aws.ecr.RepositoryPolicy | pulumi.Output<aws.ecr.RepositoryPolicy>;
+    public settings!: RepositorySettingsOutputs | pulumi.Output<RepositorySettingsOutputs>;
     public url!: string | pulumi.Output<string>;
     constructor(name: string, args: pulumi.Inputs, opts: pulumi.ComponentResourceOptions = {}) {
-        super("awsx:ecr:Repository", name, opts.urn ? { lifecyclePolicy: undefined, repository: undefined, url: undefined } : { name, args, opts }, opts);
+        super("awsx:ecr:Repository", name, opts.urn ? { lifecyclePolicy: undefined, replicaUrls: undefined, repository: undefined, repositoryPolicy: undefined, settings: undefined, url: undefined } : { name, args, opts }, opts);
     }
 }
 export interface RepositoryArgs {
+    readonly access?: RepositoryAccessInputs;
     readonly encryptionConfigurations?: pulumi.Input<pulumi.Input<aws.types.input.ecr.RepositoryEncryptionConfiguration>[]>;
     readonly forceDelete?: pulumi.Input<boolean>;
     readonly imageScanningConfiguration?: pulumi.Input<aws.types.input.ecr.RepositoryImageScanningConfiguration>;
@@ -157,14 +211,20 @@ export interface RepositoryArgs {
     readonly imageTagMutabilityExclusionFilters?: pulumi.Input<pulumi.Input<aws.types.input.ecr.RepositoryImageTagMutabilityExclusionFilter>[]>;
     readonly lifecyclePolicy?: lifecyclePolicyInputs;
     readonly name?: pulumi.Input<string>;
+    readonly namingPolicy?: RepositoryNamingPolicyInputs;
+    readonly preset?: RepositoryPresetInputs;
     readonly region?: pulumi.Input<string>;
+    readonly registry?: Registry;
     readonly tags?: pulumi.Input<Record<string, pulumi.Input<string>>>;
 }
 export abstract class EC2Service<TData = any> extends (pulumi.ComponentResource)<TData> {
+    public execBucket?: aws.s3.Bucket | pulumi.Output<aws.s3.Bucket>;
+    public execLogGroup?: aws.cloudwatch.LogGroup | pulumi.Output<aws.cloudwatch.LogGroup>;
+    public loadBalancerIngressSecurityGroup?: aws.ec2.SecurityGroup | pulumi.Output<aws.ec2.SecurityGroup>;
     public service!: aws.ecs.Service | pulumi.Output<aws.ecs.Service>;
     public taskDefinition?: aws.ecs.TaskDefinition | pulumi.Output<aws.ecs.TaskDefinition>;
     constructor(name: string, args: pulumi.Inputs, opts: pulumi.ComponentResourceOptions = {}) {
-        super("awsx:ecs:EC2Service", name, opts.urn ? { service: undefined, taskDefinition: undefined } : { name, args, opts }, opts);
+        super("awsx:ecs:EC2Service", name, opts.urn ? { execBucket: undefined, execLogGroup: undefined, loadBalancerIngressSecurityGroup: undefined, service: undefined, taskDefinition: undefined } : { name, args, opts }, opts);
     }
 }
 export interface EC2ServiceArgs {
@@ -181,10 +241,12 @@ export interface EC2ServiceArgs {
     readonly desiredCount?: pulumi.Input<number>;
     readonly enableEcsManagedTags?: pulumi.Input<boolean>;
     readonly enableExecuteCommand?: pulumi.Input<boolean>;
+    readonly exec?: ServiceExecInputs;
     readonly forceDelete?: pulumi.Input<boolean>;
     readonly forceNewDeployment?: pulumi.Input<boolean>;
     readonly healthCheckGracePeriodSeconds?: pulumi.Input<number>;
     readonly iamRole?: pulumi.Input<string>;
+    readonly loadBalancerSecurityGroups?: ServiceLoadBalancerSecurityGroupInputs[];
     readonly loadBalancers?: pulumi.Input<pulumi.Input<aws.types.input.ecs.ServiceLoadBalancer>[]>;
     readonly name?: pulumi.Input<string>;
     readonly networkConfiguration?: pulumi.Input<aws.types.input.ecs.ServiceNetworkConfiguration>;
@@ -219,6 +281,7 @@ export interface EC2TaskDefinitionArgs {
     readonly container?: TaskDefinitionContainerDefinitionInputs;
     readonly containers?: Record<string, TaskDefinitionContainerDefinitionInputs>;
     readonly cpu?: pulumi.Input<string>;
+    readonly efsVolumes?: Record<string, TaskDefinitionEfsVolumeInputs>;
     readonly enableFaultInjection?: pulumi.Input<boolean>;
     readonly ephemeralStorage?: pulumi.Input<aws.types.input.ecs.TaskDefinitionEphemeralStorage>;
     readonly executionRole?: DefaultRoleWithPolicyInputs;
@@ -232,6 +295,7 @@ export interface EC2TaskDefinitionArgs {
     readonly proxyConfiguration?: pulumi.Input<aws.types.input.ecs.TaskDefinitionProxyConfiguration>;
     readonly region?: pulumi.Input<string>;
     readonly runtimePlatform?: pulumi.Input<aws.types.input.ecs.TaskDefinitionRuntimePlatform>;
+    readonly sidecars?: TaskDefinitionSidecarInputs[];
     readonly skipDestroy?: pulumi.Input<boolean>;
     readonly tags?: pulumi.Input<Record<string, pulumi.Input<string>>>;
     readonly taskRole?: DefaultRoleWithPolicyInputs;
@@ -239,10 +303,15 @@ export interface EC2TaskDefinitionArgs {
     readonly volumes?: pulumi.Input<pulumi.Input<aws.types.input.ecs.TaskDefinitionVolume>[]>;
 }
 export abstract class FargateService<TData = any> extends (pulumi.ComponentResource)<TData> {
+    public execBucket?: aws.s3.Bucket | pulumi.Output<aws.s3.Bucket>;
+    public execLogGroup?: aws.cloudwatch.LogGroup | pulumi.Output<aws.cloudwatch.LogGroup>;
+    public frontendDnsNames?: Record<string, string> | pulumi.Output<Record<string, string>>;
+    public frontends?: Record<string, ApplicationLoadBalancer> | pulumi.Output<Record<string, ApplicationLoadBalancer>>;
+    public loadBalancerIngressSecurityGroup?: aws.ec2.SecurityGroup | pulumi.Output<aws.ec2.SecurityGroup>;
     public service!: aws.ecs.Service | pulumi.Output<aws.ecs.Service>;
     public taskDefinition?: aws.ecs.TaskDefinition | pulumi.Output<aws.ecs.TaskDefinition>;
     constructor(name: string, args: pulumi.Inputs, opts: pulumi.ComponentResourceOptions = {}) {
-        super("awsx:ecs:FargateService", name, opts.urn ? { service: undefined, taskDefinition: undefined } : { name, args, opts }, opts);
+        super("awsx:ecs:FargateService", name, opts.urn ? { execBucket: undefined, execLogGroup: undefined, frontendDnsNames: undefined, frontends: undefined, loadBalancerIngressSecurityGroup: undefined, service: undefined, taskDefinition: undefined } : { name, args, opts }, opts);
     }
 }
 export interface FargateServiceArgs {
@@ -260,10 +329,13 @@ export interface FargateServiceArgs {
     readonly desiredCount?: pulumi.Input<number>;
     readonly enableEcsManagedTags?: pulumi.Input<boolean>;
     readonly enableExecuteCommand?: pulumi.Input<boolean>;
+    readonly exec?: ServiceExecInputs;
     readonly forceDelete?: pulumi.Input<boolean>;
     readonly forceNewDeployment?: pulumi.Input<boolean>;
+    readonly frontends?: Record<string, ServiceFrontendInputs>;
     readonly healthCheckGracePeriodSeconds?: pulumi.Input<number>;
     readonly iamRole?: pulumi.Input<string>;
+    readonly loadBalancerSecurityGroups?: ServiceLoadBalancerSecurityGroupInputs[];
     readonly loadBalancers?: pulumi.Input<pulumi.Input<aws.types.input.ecs.ServiceLoadBalancer>[]>;
     readonly name?: pulumi.Input<string>;
     readonly networkConfiguration?: pulumi.Input<aws.types.input.ecs.ServiceNetworkConfiguration>;
@@ -281,6 +353,7 @@ export interface FargateServiceArgs {
     readonly triggers?: pulumi.Input<Record<string, pulumi.Input<string>>>;
     readonly useClusterDefaultCapacityProviderStrategy?: boolean;
     readonly volumeConfiguration?: pulumi.Input<aws.types.input.ecs.ServiceVolumeConfiguration>;
+    readonly vpc?: Vpc;
     readonly vpcLatticeConfigurations?: pulumi.Input<pulumi.Input<aws.types.input.ecs.ServiceVpcLatticeConfiguration>[]>;
 }
 export abstract class FargateTaskDefinition<TData = any> extends (pulumi.ComponentResource)<TData> {
@@ -294,9 +367,11 @@ export abstract class FargateTaskDefinition<TData = any> extends (pulumi.Compone
     }
 }
 export interface FargateTaskDefinitionArgs {
+    readonly architecture?: FargateCpuArchitectureInputs;
     readonly container?: TaskDefinitionContainerDefinitionInputs;
     readonly containers?: Record<string, TaskDefinitionContainerDefinitionInputs>;
     readonly cpu?: pulumi.Input<string>;
+    readonly efsVolumes?: Record<string, TaskDefinitionEfsVolumeInputs>;
     readonly enableFaultInjection?: pulumi.Input<boolean>;
     readonly ephemeralStorage?: pulumi.Input<aws.types.input.ecs.TaskDefinitionEphemeralStorage>;
     readonly executionRole?: DefaultRoleWithPolicyInputs;
@@ -309,24 +384,53 @@ export interface FargateTaskDefinitionArgs {
     readonly proxyConfiguration?: pulumi.Input<aws.types.input.ecs.TaskDefinitionProxyConfiguration>;
     readonly region?: pulumi.Input<string>;
     readonly runtimePlatform?: pulumi.Input<aws.types.input.ecs.TaskDefinitionRuntimePlatform>;
+    readonly sidecars?: TaskDefinitionSidecarInputs[];
+    readonly size?: FargateTaskSizeInputs;
     readonly skipDestroy?: pulumi.Input<boolean>;
     readonly tags?: pulumi.Input<Record<string, pulumi.Input<string>>>;
     readonly taskRole?: DefaultRoleWithPolicyInputs;
     readonly trackLatest?: pulumi.Input<boolean>;
     readonly volumes?: pulumi.Input<pulumi.Input<aws.types.input.ecs.TaskDefinitionVolume>[]>;
 }
+export abstract class FileSystem<TData = any> extends (pulumi.ComponentResource)<TData> {
+    public clientSecurityGroup?: aws.ec2.SecurityGroup | pulumi.Output<aws.ec2.SecurityGroup>;
+    public fileSystem!: aws.efs.FileSystem | pulumi.Output<aws.efs.FileSystem>;
+    public mountTargets!: aws.efs.MountTarget[] | pulumi.Output<aws.efs.MountTarget[]>;
+    public securityGroup?: aws.ec2.SecurityGroup | pulumi.Output<aws.ec2.SecurityGroup>;
+    constructor(name: string, args: pulumi.Inputs, opts: pulumi.ComponentResourceOptions = {}) {
+        super("awsx:efs:FileSystem", name, opts.urn ? { clientSecurityGroup: undefined, fileSystem: undefined, mountTargets: undefined, securityGroup: undefined } : { name, args, opts }, opts);
+    }
+}
+export interface FileSystemArgs {
+    readonly defaultSecurityGroup?: DefaultSecurityGroupInputs;
+    readonly encrypted?: pulumi.Input<boolean>;
+    readonly kmsKeyId?: pulumi.Input<string>;
+    readonly performanceMode?: pulumi.Input<string>;
+    readonly provisionedThroughputInMibps?: pulumi.Input<number>;
+    readonly region?: pulumi.Input<string>;
+    readonly subnetIds: pulumi.Input<string>[];
+    readonly tags?: pulumi.Input<Record<string, pulumi.Input<string>>>;
+    readonly throughputMode?: pulumi.Input<string>;
+}
 export abstract class ApplicationLoadBalancer<TData = any> extends (pulumi.ComponentResource)<TData> {
+    public accessLogsBucket?: aws.s3.Bucket | pulumi.Output<aws.s3.Bucket>;
+    public certificate?: aws.acm.Certificate | pulumi.Output<aws.acm.Certificate>;
     public defaultSecurityGroup?: aws.ec2.SecurityGroup | pulumi.Output<aws.ec2.SecurityGroup>;
     public defaultTargetGroup!: aws.lb.TargetGroup | pulumi.Output<aws.lb.TargetGroup>;
+    public dnsName?: string | pulumi.Output<string>;
+    public listenerRules?: aws.lb.ListenerRule[][] | pulumi.Output<aws.lb.ListenerRule[][]>;
     public listeners?: aws.lb.Listener[] | pulumi.Output<aws.lb.Listener[]>;
     public loadBalancer!: aws.lb.LoadBalancer | pulumi.Output<aws.lb.LoadBalancer>;
+    public targetGroups?: Record<string, aws.lb.TargetGroup> | pulumi.Output<Record<string, aws.lb.TargetGroup>>;
     public vpcId?: string | pulumi.Output<string>;
+    public webAcl?: aws.wafv2.WebAcl | pulumi.Output<aws.wafv2.WebAcl>;
     constructor(name: string, args: pulumi.Inputs, opts: pulumi.ComponentResourceOptions = {}) {
-        super("awsx:lb:ApplicationLoadBalancer", name, opts.urn ? { defaultSecurityGroup: undefined, defaultTargetGroup: undefined, listeners: undefined, loadBalancer: undefined, vpcId: undefined } : { name, args, opts }, opts);
+        super("awsx:lb:ApplicationLoadBalancer", name, opts.urn ? { accessLogsBucket: undefined, certificate: undefined, defaultSecurityGroup: undefined, defaultTargetGroup: undefined, dnsName: undefined, listenerRules: undefined, listeners: undefined, loadBalancer: undefined, targetGroups: undefined, vpcId: undefined, webAcl: undefined } : { name, args, opts }, opts);
     }
 }
 export interface ApplicationLoadBalancerArgs {
     readonly accessLogs?: pulumi.Input<aws.types.input.lb.LoadBalancerAccessLogs>;
+    readonly accessLogsBucket?: AccessLogsInputs;
     readonly clientKeepAlive?: pulumi.Input<number>;
     readonly connectionLogs?: pulumi.Input<aws.types.input.lb.LoadBalancerConnectionLogs>;
     readonly customerOwnedIpv4Pool?: pulumi.Input<string>;
@@ -345,6 +449,7 @@ export interface ApplicationLoadBalancerArgs {
     readonly enableZonalShift?: pulumi.Input<boolean>;
     readonly enforceSecurityGroupInboundRulesOnPrivateLinkTraffic?: pulumi.Input<string>;
     readonly healthCheckLogs?: pulumi.Input<aws.types.input.lb.LoadBalancerHealthCheckLogs>;
+    readonly https?: ApplicationLoadBalancerHttpsInputs;
     readonly idleTimeout?: pulumi.Input<number>;
     readonly internal?: pulumi.Input<boolean>;
     readonly ipAddressType?: pulumi.Input<string>;
@@ -362,15 +467,62 @@ export interface ApplicationLoadBalancerArgs {
     readonly subnetMappings?: pulumi.Input<pulumi.Input<aws.types.input.lb.LoadBalancerSubnetMapping>[]>;
     readonly subnets?: pulumi.Input<pulumi.Input<aws.ec2.Subnet>[]>;
     readonly tags?: pulumi.Input<Record<string, pulumi.Input<string>>>;
+    readonly targetGroups?: Record<string, TargetGroupInputs>;
+    readonly webAclArn?: pulumi.Input<string>;
+    readonly webAclPreset?: WebAclPresetInputs;
+    readonly weightedForwards?: Record<string, WeightedForwardInputs>;
     readonly xffHeaderProcessingMode?: pulumi.Input<string>;
 }
+export abstract class GatewayLoadBalancer<TData = any> extends (pulumi.ComponentResource)<TData> {
+    public endpointService!: aws.ec2.VpcEndpointService | pulumi.Output<aws.ec2.VpcEndpointService>;
+    public endpoints!: aws.ec2.VpcEndpoint[] | pulumi.Output<aws.ec2.VpcEndpoint[]>;
+    public listener!: aws.lb.Listener | pulumi.Output<aws.lb.Listener>;
+    public loadBalancer!: aws.lb.LoadBalancer | pulumi.Output<aws.lb.LoadBalancer>;
+    public routes!: aws.ec2.Route[] | pulumi.Output<aws.ec2.Route[]>;
+    public targetGroup!: aws.lb.TargetGroup | pulumi.Output<aws.lb.TargetGroup>;
+    constructor(name: string, args: pulumi.Inputs, opts: pulumi.ComponentResourceOptions = {}) {
+        super("awsx:lb:GatewayLoadBalancer", name, opts.urn ? { endpointService: undefined, endpoints: undefined, listener: undefined, loadBalancer: undefined, routes: undefined, targetGroup: undefined } : { name, args, opts }, opts);
+    }
+}
+export interface GatewayLoadBalancerArgs {
+    readonly allowedPrincipals?: pulumi.Input<pulumi.Input<string>[]>;
+    readonly enableCrossZoneLoadBalancing?: pulumi.Input<boolean>;
+    readonly endpoints?: GatewayLoadBalancerEndpointInputs[];
+    readonly region?: pulumi.Input<string>;
+    readonly subnetIds: pulumi.Input<pulumi.Input<string>[]>;
+    readonly tags?: pulumi.Input<Record<string, pulumi.Input<string>>>;
+    readonly targetGroup?: TargetGroupInputs;
+}
+export abstract class LambdaTarget<TData = any> extends (pulumi.ComponentResource)<TData> {
+    public lambdaPermission!: aws.lambda.Permission | pulumi.Output<aws.lambda.Permission>;
+    public listenerRule!: aws.lb.ListenerRule | pulumi.Output<aws.lb.ListenerRule>;
+    public targetGroup!: aws.lb.TargetGroup | pulumi.Output<aws.lb.TargetGroup>;
+    public targetGroupAttachment!: aws.lb.TargetGroupAttachment | pulumi.Output<aws.lb.TargetGroupAttachment>;
+    constructor(name: string, args: pulumi.Inputs, opts: pulumi.ComponentResourceOptions = {}) {
+        super("awsx:lb:LambdaTarget", name, opts.urn ? { lambdaPermission: undefined, listenerRule: undefined, targetGroup: undefined, targetGroupAttachment: undefined } : { name, args, opts }, opts);
+    }
+}
+export interface LambdaTargetArgs {
+    readonly healthCheck?: pulumi.Input<aws.types.input.lb.TargetGroupHealthCheck>;
+    readonly hostHeaders?: pulumi.Input<pulumi.Input<string>[]>;
+    readonly lambda?: pulumi.Input<aws.lambda.Function>;
+    readonly lambdaArn?: pulumi.Input<string>;
+    readonly listenerArn: pulumi.Input<string>;
+    readonly multiValueHeadersEnabled?: pulumi.Input<boolean>;
+    readonly pathPatterns?: pulumi.Input<pulumi.Input<string>[]>;
+    readonly priority?: pulumi.Input<number>;
+    readonly region?: pulumi.Input<string>;
+    readonly tags?: pulumi.Input<Record<string, pulumi.Input<string>>>;
+}
 export abstract class NetworkLoadBalancer<TData = any> extends (pulumi.ComponentResource)<TData> {
+    public certificate?: aws.acm.Certificate | pulumi.Output<aws.acm.Certificate>;
     public defaultTargetGroup!: aws.lb.TargetGroup | pulumi.Output<aws.lb.TargetGroup>;
     public listeners?: aws.lb.Listener[] | pulumi.Output<aws.lb.Listener[]>;
     public loadBalancer!: aws.lb.LoadBalancer | pulumi.Output<aws.lb.LoadBalancer>;
+    public targetGroups?: Record<string, aws.lb.TargetGroup> | pulumi.Output<Record<string, aws.lb.TargetGroup>>;
     public vpcId?: string | pulumi.Output<string>;
     constructor(name: string, args: pulumi.Inputs, opts: pulumi.ComponentResourceOptions = {}) {
-        super("awsx:lb:NetworkLoadBalancer", name, opts.urn ? { defaultTargetGroup: undefined, listeners: undefined, loadBalancer: undefined, vpcId: undefined } : { name, args, opts }, opts);
+        super("awsx:lb:NetworkLoadBalancer", name, opts.urn ? { certificate: undefined, defaultTargetGroup: undefined, listeners: undefined, loadBalancer: undefined, targetGroups: undefined, vpcId: undefined } : { name, args, opts }, opts);
     }
 }
 export interface NetworkLoadBalancerArgs {
@@ -409,20 +561,29 @@ export interface NetworkLoadBalancerArgs {
     readonly subnetMappings?: pulumi.Input<pulumi.Input<aws.types.input.lb.LoadBalancerSubnetMapping>[]>;
     readonly subnets?: pulumi.Input<pulumi.Input<aws.ec2.Subnet>[]>;
     readonly tags?: pulumi.Input<Record<string, pulumi.Input<string>>>;
+    readonly targetGroups?: Record<string, TargetGroupInputs>;
     readonly xffHeaderProcessingMode?: pulumi.Input<string>;
 }
 export abstract class TargetGroupAttachment<TData = any> extends (pulumi.ComponentResource)<TData> {
+    public autoScalingAttachment?: aws.autoscaling.Attachment | pulumi.Output<aws.autoscaling.Attachment>;
     public lambdaPermission?: aws.lambda.Permission | pulumi.Output<aws.lambda.Permission>;
-    public targetGroupAttachment!: aws.lb.TargetGroupAttachment | pulumi.Output<aws.lb.TargetGroupAttachment>;
+    public targetGroupAttachment?: aws.lb.TargetGroupAttachment | pulumi.Output<aws.lb.TargetGroupAttachment>;
     constructor(name: string, args: pulumi.Inputs, opts: pulumi.ComponentResourceOptions = {}) {
-        super("awsx:lb:TargetGroupAttachment", name, opts.urn ? { lambdaPermission: undefined, targetGroupAttachment: undefined } : { name, args, opts }, opts);
+        super("awsx:lb:TargetGroupAttachment", name, opts.urn ? { autoScalingAttachment: undefined, lambdaPermission: undefined, targetGroupAttachment: undefined } : { name, args, opts }, opts);
     }
 }
 export interface TargetGroupAttachmentArgs {
+    readonly autoScalingGroup?: pulumi.Input<aws.autoscaling.Group>;
+    readonly autoScalingGroupName?: pulumi.Input<string>;
+    readonly availabilityZone?: pulumi.Input<string>;
     readonly instance?: pulumi.Input<aws.ec2.Instance>;
     readonly instanceId?: pulumi.Input<string>;
+    readonly ipAddress?: pulumi.Input<string>;
     readonly lambda?: pulumi.Input<aws.lambda.Function>;
     readonly lambdaArn?: pulumi.Input<string>;
+    readonly loadBalancer?: pulumi.Input<aws.lb.LoadBalancer>;
+    readonly loadBalancerArn?: pulumi.Input<string>;
+    readonly port?: pulumi.Input<number>;
     readonly targetGroup?: pulumi.Input<aws.lb.TargetGroup>;
     readonly targetGroupArn?: pulumi.Input<string>;
 }
@@ -722,30 +883,222 @@ export interface VpcEndpointSpecOutputs {
 }
 export type VpcEndpointStrategyInputs = "Legacy" | "Auto";
 export type VpcEndpointStrategyOutputs = "Legacy" | "Auto";
+export interface BuildCacheInputs {
+    readonly mode?: pulumi.Input<BuildCacheModeInputs>;
+    readonly repositoryUrl?: pulumi.Input<string>;
+    readonly tag?: pulumi.Input<string>;
+}
+export interface BuildCacheOutputs {
+    readonly mode?: pulumi.Output<BuildCacheModeOutputs>;
+    readonly repositoryUrl?: pulumi.Output<string>;
+    readonly tag?: pulumi.Output<string>;
+}
+export type BuildCacheModeInputs = "min" | "max";
+export type BuildCacheModeOutputs = "min" | "max";
+export interface BuildSecretInputs {
+    readonly env?: string;
+    readonly src?: string;
+}
+export interface BuildSecretOutputs {
+    readonly env?: string;
+    readonly src?: string;
+}
+export interface BuildSshInputs {
+    readonly id: pulumi.Input<string>;
+    readonly paths?: pulumi.Input<pulumi.Input<string>[]>;
+}
+export interface BuildSshOutputs {
+    readonly id: pulumi.Output<string>;
+    readonly paths?: pulumi.Output<string[]>;
+}
 export type BuilderVersionInputs = "BuilderV1" | "BuilderBuildKit";
 export type BuilderVersionOutputs = "BuilderV1" | "BuilderBuildKit";
 export interface DockerBuildInputs {
     readonly args?: pulumi.Input<Record<string, pulumi.Input<string>>>;
     readonly builderVersion?: BuilderVersionInputs;
+    readonly cache?: pulumi.Input<BuildCacheInputs>;
     readonly cacheFrom?: pulumi.Input<pulumi.Input<string>[]>;
     readonly context?: pulumi.Input<string>;
     readonly dockerfile?: pulumi.Input<string>;
     readonly imageName?: pulumi.Input<string>;
     readonly imageTag?: pulumi.Input<string>;
     readonly platform?: pulumi.Input<string>;
+    readonly platforms?: pulumi.Input<pulumi.Input<string>[]>;
+    readonly secrets?: Record<string, BuildSecretInputs>;
+    readonly ssh?: pulumi.Input<pulumi.Input<BuildSshInputs>[]>;
     readonly target?: pulumi.Input<string>;
 }
 export interface DockerBuildOutputs {
     readonly args?: pulumi.Output<Record<string, string>>;
     readonly builderVersion?: BuilderVersionOutputs;
+    readonly cache?: pulumi.Output<BuildCacheOutputs>;
     readonly cacheFrom?: pulumi.Output<string[]>;
     readonly context?: pulumi.Output<string>;
     readonly dockerfile?: pulumi.Output<string>;
     readonly imageName?: pulumi.Output<string>;
     readonly imageTag?: pulumi.Output<string>;
     readonly platform?: pulumi.Output<string>;
+    readonly platforms?: pulumi.Output<string[]>;
+    readonly secrets?: Record<string, BuildSecretOutputs>;
+    readonly ssh?: pulumi.Output<BuildSshOutputs[]>;
     readonly target?: pulumi.Output<string>;
 }
+export interface ImageAttestationsInputs {
+    readonly provenance?: pulumi.Input<boolean>;
+    readonly sbom?: pulumi.Input<boolean>;
+}
+export interface ImageAttestationsOutputs {
+    readonly provenance?: pulumi.Output<boolean>;
+    readonly sbom?: pulumi.Output<boolean>;
+}
+export interface ImageCopySourceInputs {
+    readonly digest?: pulumi.Input<string>;
+    readonly repositoryUrl: pulumi.Input<string>;
+    readonly tag?: pulumi.Input<string>;
+}
+export interface ImageCopySourceOutputs {
+    readonly digest?: pulumi.Output<string>;
+    readonly repositoryUrl: pulumi.Output<string>;
+    readonly tag?: pulumi.Output<string>;
+}
+export interface ImageSigningInputs {
+    readonly kmsKeyArn?: pulumi.Input<string>;
+    readonly signingProfileArn?: pulumi.Input<string>;
+}
+export interface ImageSigningOutputs {
+    readonly kmsKeyArn?: pulumi.Output<string>;
+    readonly signingProfileArn?: pulumi.Output<string>;
+}
+export interface PullThroughCacheRuleInputs {
+    readonly accessToken?: pulumi.Input<string>;
+    readonly credentialArn?: pulumi.Input<string>;
+    readonly upstream: PullThroughCacheUpstreamInputs;
+    readonly username?: pulumi.Input<string>;
+}
+export interface PullThroughCacheRuleOutputs {
+    readonly accessToken?: pulumi.Output<string>;
+    readonly credentialArn?: pulumi.Output<string>;
+    readonly upstream: PullThroughCacheUpstreamOutputs;
+    readonly username?: pulumi.Output<string>;
+}
+export type PullThroughCacheUpstreamInputs = "docker-hub" | "github-container-registry" | "quay";
+export type PullThroughCacheUpstreamOutputs = "docker-hub" | "github-container-registry" | "quay";
+export interface RegistryReplicationInputs {
+    readonly destinations: ReplicationDestinationInputs[];
+    readonly repositoryPrefixes?: string[];
+}
+export interface RegistryReplicationOutputs {
+    readonly destinations: ReplicationDestinationOutputs[];
+    readonly repositoryPrefixes?: string[];
+}
+export interface RegistryScanningInputs {
+    readonly rules?: pulumi.Input<pulumi.Input<RegistryScanningRuleInputs>[]>;
+    readonly scanType?: pulumi.Input<ScanTypeInputs>;
+}
+export interface RegistryScanningOutputs {
+    readonly rules?: pulumi.Output<RegistryScanningRuleOutputs[]>;
+    readonly scanType?: pulumi.Output<ScanTypeOutputs>;
+}
+export interface RegistryScanningRuleInputs {
+    readonly frequency: pulumi.Input<ScanFrequencyInputs>;
+    readonly repositoryFilters?: pulumi.Input<pulumi.Input<string>[]>;
+}
+export interface RegistryScanningRuleOutputs {
+    readonly frequency: pulumi.Output<ScanFrequencyOutputs>;
+    readonly repositoryFilters?: pulumi.Output<string[]>;
+}
+export interface ReplicationDestinationInputs {
+    readonly region: string;
+    readonly registryId?: string;
+}
+export interface ReplicationDestinationOutputs {
+    readonly region: string;
+    readonly registryId?: string;
+}
+export interface RepositoryAccessInputs {
+    readonly lambda?: RepositoryLambdaAccessInputs;
+    readonly pull?: RepositoryAccessGrantInputs;
+    readonly push?: RepositoryAccessGrantInputs;
+}
+export interface RepositoryAccessOutputs {
+    readonly lambda?: RepositoryLambdaAccessOutputs;
+    readonly pull?: RepositoryAccessGrantOutputs;
+    readonly push?: RepositoryAccessGrantOutputs;
+}
+export interface RepositoryAccessGrantInputs {
+    readonly accountIds?: pulumi.Input<pulumi.Input<string>[]>;
+    readonly organizationIds?: pulumi.Input<pulumi.Input<string>[]>;
+    readonly principals?: pulumi.Input<pulumi.Input<string>[]>;
+}
+export interface RepositoryAccessGrantOutputs {
+    readonly accountIds?: pulumi.Output<string[]>;
+    readonly organizationIds?: pulumi.Output<string[]>;
+    readonly principals?: pulumi.Output<string[]>;
+}
+export interface RepositoryLambdaAccessInputs {
+    readonly sourceArns: pulumi.Input<pulumi.Input<string>[]>;
+}
+export interface RepositoryLambdaAccessOutputs {
+    readonly sourceArns: pulumi.Output<string[]>;
+}
+export interface RepositoryNamingPolicyInputs {
+    readonly pattern?: string;
+    readonly prefix?: string;
+}
+export interface RepositoryNamingPolicyOutputs {
+    readonly pattern?: string;
+    readonly prefix?: string;
+}
+export type RepositoryPresetInputs = "secure-default";
+export type RepositoryPresetOutputs = "secure-default";
+export interface RepositorySettingsInputs {
+    readonly encryptionType: pulumi.Input<string>;
+    readonly imageTagMutability: pulumi.Input<string>;
+    readonly kmsKey?: pulumi.Input<string>;
+    readonly lifecyclePolicy?: pulumi.Input<string>;
+    readonly preset?: pulumi.Input<string>;
+    readonly scanOnPush: pulumi.Input<boolean>;
+}
+export interface RepositorySettingsOutputs {
+    readonly encryptionType: pulumi.Output<string>;
+    readonly imageTagMutability: pulumi.Output<string>;
+    readonly kmsKey?: pulumi.Output<string>;
+    readonly lifecyclePolicy?: pulumi.Output<string>;
+    readonly preset?: pulumi.Output<string>;
+    readonly scanOnPush: pulumi.Output<boolean>;
+}
+export interface ScanFindingsSummaryInputs {
+    readonly allowedVulnerabilities: pulumi.Input<pulumi.Input<string>[]>;
+    readonly blockingVulnerabilities: pulumi.Input<pulumi.Input<string>[]>;
+    readonly severityCounts: pulumi.Input<Record<string, pulumi.Input<number>>>;
+}
+export interface ScanFindingsSummaryOutputs {
+    readonly allowedVulnerabilities: pulumi.Output<string[]>;
+    readonly blockingVulnerabilities: pulumi.Output<string[]>;
+    readonly severityCounts: pulumi.Output<Record<string, number>>;
+}
+export type ScanFrequencyInputs = "SCAN_ON_PUSH" | "CONTINUOUS_SCAN" | "MANUAL";
+export type ScanFrequencyOutputs = "SCAN_ON_PUSH" | "CONTINUOUS_SCAN" | "MANUAL";
+export interface ScanGateInputs {
+    readonly allowedVulnerabilities?: pulumi.Input<pulumi.Input<string>[]>;
+    readonly severityThreshold?: pulumi.Input<ScanSeverityInputs>;
+    readonly timeoutSeconds?: pulumi.Input<number>;
+}
+export interface ScanGateOutputs {
+    readonly allowedVulnerabilities?: pulumi.Output<string[]>;
+    readonly severityThreshold?: pulumi.Output<ScanSeverityOutputs>;
+    readonly timeoutSeconds?: pulumi.Output<number>;
+}
+export type ScanSeverityInputs = "INFORMATIONAL" | "LOW" | "MEDIUM" | "HIGH" | "CRITICAL";
+export type ScanSeverityOutputs = "INFORMATIONAL" | "LOW" | "MEDIUM" | "HIGH" | "CRITICAL";
+export type ScanTypeInputs = "BASIC" | "ENHANCED";
+export type ScanTypeOutputs = "BASIC" | "ENHANCED";
+export type lifecycleActionInputs = "expire" | "archive";
+export type lifecycleActionOutputs = "expire" | "archive";
+export type lifecycleAgeSinceInputs = "imagePushed" | "imagePulled" | "imageTransitioned";
+export type lifecycleAgeSinceOutputs = "imagePushed" | "imagePulled" | "imageTransitioned";
+export type lifecycleAgeUnitInputs = "days" | "weeks";
+export type lifecycleAgeUnitOutputs = "days" | "weeks";
 export interface lifecyclePolicyInputs {
     readonly rules?: pulumi.Input<pulumi.Input<lifecyclePolicyRuleInputs>[]>;
     readonly skip?: boolean;
@@ -755,25 +1108,40 @@ export interface lifecyclePolicyOutputs {
     readonly skip?: boolean;
 }
 export interface lifecyclePolicyRuleInputs {
+    readonly action?: pulumi.Input<lifecycleActionInputs>;
     readonly description?: pulumi.Input<string>;
     readonly maximumAgeLimit?: pulumi.Input<number>;
+    readonly maximumAgeSince?: pulumi.Input<lifecycleAgeSinceInputs>;
+    readonly maximumAgeUnit?: pulumi.Input<lifecycleAgeUnitInputs>;
     readonly maximumNumberOfImages?: pulumi.Input<number>;
+    readonly priority?: pulumi.Input<number>;
+    readonly storageClass?: pulumi.Input<lifecycleStorageClassInputs>;
+    readonly tagPatternList?: pulumi.Input<pulumi.Input<string>[]>;
     readonly tagPrefixList?: pulumi.Input<pulumi.Input<string>[]>;
     readonly tagStatus: pulumi.Input<lifecycleTagStatusInputs>;
 }
 export interface lifecyclePolicyRuleOutputs {
+    readonly action?: pulumi.Output<lifecycleActionOutputs>;
     readonly description?: pulumi.Output<string>;
     readonly maximumAgeLimit?: pulumi.Output<number>;
+    readonly maximumAgeSince?: pulumi.Output<lifecycleAgeSinceOutputs>;
+    readonly maximumAgeUnit?: pulumi.Output<lifecycleAgeUnitOutputs>;
     readonly maximumNumberOfImages?: pulumi.Output<number>;
+    readonly priority?: pulumi.Output<number>;
+    readonly storageClass?: pulumi.Output<lifecycleStorageClassOutputs>;
+    readonly tagPatternList?: pulumi.Output<string[]>;
     readonly tagPrefixList?: pulumi.Output<string[]>;
     readonly tagStatus: pulumi.Output<lifecycleTagStatusOutputs>;
 }
+export type lifecycleStorageClassInputs = "standard" | "archive";
+export type lifecycleStorageClassOutputs = "standard" | "archive";
 export type lifecycleTagStatusInputs = "any" | "untagged" | "tagged";
 export type lifecycleTagStatusOutputs = "any" | "untagged" | "tagged";
 export interface EC2ServiceTaskDefinitionInputs {
     readonly container?: TaskDefinitionContainerDefinitionInputs;
     readonly containers?: Record<string, TaskDefinitionContainerDefinitionInputs>;
     readonly cpu?: pulumi.Input<string>;
+    readonly efsVolumes?: Record<string, TaskDefinitionEfsVolumeInputs>;
     readonly enableFaultInjection?: pulumi.Input<boolean>;
     readonly ephemeralStorage?: pulumi.Input<aws.types.input.ecs.TaskDefinitionEphemeralStorage>;
     readonly executionRole?: DefaultRoleWithPolicyInputs;
@@ -787,6 +1155,7 @@ export interface EC2ServiceTaskDefinitionInputs {
     readonly proxyConfiguration?: pulumi.Input<aws.types.input.ecs.TaskDefinitionProxyConfiguration>;
     readonly region?: pulumi.Input<string>;
     readonly runtimePlatform?: pulumi.Input<aws.types.input.ecs.TaskDefinitionRuntimePlatform>;
+    readonly sidecars?: TaskDefinitionSidecarInputs[];
     readonly skipDestroy?: pulumi.Input<boolean>;
     readonly tags?: pulumi.Input<Record<string, pulumi.Input<string>>>;
     readonly taskRole?: DefaultRoleWithPolicyInputs;
@@ -797,6 +1166,7 @@ export interface EC2ServiceTaskDefinitionOutputs {
     readonly container?: TaskDefinitionContainerDefinitionOutputs;
     readonly containers?: Record<string, TaskDefinitionContainerDefinitionOutputs>;
     readonly cpu?: pulumi.Output<string>;
+    readonly efsVolumes?: Record<string, TaskDefinitionEfsVolumeOutputs>;
     readonly enableFaultInjection?: pulumi.Output<boolean>;
     readonly ephemeralStorage?: pulumi.Output<aws.types.output.ecs.TaskDefinitionEphemeralStorage>;
     readonly executionRole?: DefaultRoleWithPolicyOutputs;
@@ -810,16 +1180,21 @@ export interface EC2ServiceTaskDefinitionOutputs {
     readonly proxyConfiguration?: pulumi.Output<aws.types.output.ecs.TaskDefinitionProxyConfiguration>;
     readonly region?: pulumi.Output<string>;
     readonly runtimePlatform?: pulumi.Output<aws.types.output.ecs.TaskDefinitionRuntimePlatform>;
+    readonly sidecars?: TaskDefinitionSidecarOutputs[];
     readonly skipDestroy?: pulumi.Output<boolean>;
     readonly tags?: pulumi.Output<Record<string, string>>;
     readonly taskRole?: DefaultRoleWithPolicyOutputs;
     readonly trackLatest?: pulumi.Output<boolean>;
     readonly volumes?: pulumi.Output<aws.types.output.ecs.TaskDefinitionVolume[]>;
 }
+export type FargateCpuArchitectureInputs = "X86_64" | "ARM64";
+export type FargateCpuArchitectureOutputs = "X86_64" | "ARM64";
 export interface FargateServiceTaskDefinitionInputs {
+    readonly architecture?: FargateCpuArchitectureInputs;
     readonly container?: TaskDefinitionContainerDefinitionInputs;
     readonly containers?: Record<string, TaskDefinitionContainerDefinitionInputs>;
     readonly cpu?: pulumi.Input<string>;
+    readonly efsVolumes?: Record<string, TaskDefinitionEfsVolumeInputs>;
     readonly enableFaultInjection?: pulumi.Input<boolean>;
     readonly ephemeralStorage?: pulumi.Input<aws.types.input.ecs.TaskDefinitionEphemeralStorage>;
     readonly executionRole?: DefaultRoleWithPolicyInputs;
@@ -832,6 +1207,8 @@ export interface FargateServiceTaskDefinitionInputs {
     readonly proxyConfiguration?: pulumi.Input<aws.types.input.ecs.TaskDefinitionProxyConfiguration>;
     readonly region?: pulumi.Input<string>;
     readonly runtimePlatform?: pulumi.Input<aws.types.input.ecs.TaskDefinitionRuntimePlatform>;
+    readonly sidecars?: TaskDefinitionSidecarInputs[];
+    readonly size?: FargateTaskSizeInputs;
     readonly skipDestroy?: pulumi.Input<boolean>;
     readonly tags?: pulumi.Input<Record<string, pulumi.Input<string>>>;
     readonly taskRole?: DefaultRoleWithPolicyInputs;
@@ -839,9 +1216,11 @@ export interface FargateServiceTaskDefinitionInputs {
     readonly volumes?: pulumi.Input<pulumi.Input<aws.types.input.ecs.TaskDefinitionVolume>[]>;
 }
 export interface FargateServiceTaskDefinitionOutputs {
+    readonly architecture?: FargateCpuArchitectureOutputs;
     readonly container?: TaskDefinitionContainerDefinitionOutputs;
     readonly containers?: Record<string, TaskDefinitionContainerDefinitionOutputs>;
     readonly cpu?: pulumi.Output<string>;
+    readonly efsVolumes?: Record<string, TaskDefinitionEfsVolumeOutputs>;
     readonly enableFaultInjection?: pulumi.Output<boolean>;
     readonly ephemeralStorage?: pulumi.Output<aws.types.output.ecs.TaskDefinitionEphemeralStorage>;
     readonly executionRole?: DefaultRoleWithPolicyOutputs;
@@ -854,12 +1233,50 @@ export interface FargateServiceTaskDefinitionOutputs {
     readonly proxyConfiguration?: pulumi.Output<aws.types.output.ecs.TaskDefinitionProxyConfiguration>;
     readonly region?: pulumi.Output<string>;
     readonly runtimePlatform?: pulumi.Output<aws.types.output.ecs.TaskDefinitionRuntimePlatform>;
+    readonly sidecars?: TaskDefinitionSidecarOutputs[];
+    readonly size?: FargateTaskSizeOutputs;
     readonly skipDestroy?: pulumi.Output<boolean>;
     readonly tags?: pulumi.Output<Record<string, string>>;
     readonly taskRole?: DefaultRoleWithPolicyOutputs;
     readonly trackLatest?: pulumi.Output<boolean>;
     readonly volumes?: pulumi.Output<aws.types.output.ecs.TaskDefinitionVolume[]>;
 }
+export type FargateTaskSizeInputs = "0.25vCPU-512MiB" | "0.25vCPU-1GiB" | "0.25vCPU-2GiB" | "0.5vCPU-1GiB" | "0.5vCPU-2GiB" | "0.5vCPU-3GiB" | "0.5vCPU-4GiB" | "1vCPU-2GiB" | "1vCPU-3GiB" | "1vCPU-4GiB" | "1vCPU-5GiB" | "1vCPU-6GiB" | "1vCPU-7GiB" | "1vCPU-8GiB" | "2vCPU-4GiB" | "2vCPU-5GiB" | "2vCPU-6GiB" | "2vCPU-7GiB" | "2vCPU-8GiB" | "2vCPU-9GiB" | "2vCPU-10GiB" | "2vCPU-11GiB" | "2vCPU-12GiB" | "2vCPU-13GiB" | "2vCPU-14GiB" | "2vCPU-15GiB" | "2vCPU-16GiB" | "4vCPU-8GiB" | "4vCPU-9GiB" | "4vCPU-10GiB" | "4vCPU-11GiB" | "4vCPU-12GiB" | "4vCPU-13GiB" | "4vCPU-14GiB" | "4vCPU-15GiB" | "4vCPU-16GiB" | "4vCPU-17GiB" | "4vCPU-18GiB" | "4vCPU-19GiB" | "4vCPU-20GiB" | "4vCPU-21GiB" | "4vCPU-22GiB" | "4vCPU-23GiB" | "4vCPU-24GiB" | "4vCPU-25GiB" | "4vCPU-26GiB" | "4vCPU-27GiB" | "4vCPU-28GiB" | "4vCPU-29GiB" | "4vCPU-30GiB" | "8vCPU-16GiB" | "8vCPU-20GiB" | "8vCPU-24GiB" | "8vCPU-28GiB" | "8vCPU-32GiB" | "8vCPU-36GiB" | "8vCPU-40GiB" | "8vCPU-44GiB" | "8vCPU-48GiB" | "8vCPU-52GiB" | "8vCPU-56GiB" | "8vCPU-60GiB" | "16vCPU-32GiB" | "16vCPU-40GiB" | "16vCPU-48GiB" | "16vCPU-56GiB" | "16vCPU-64GiB" | "16vCPU-72GiB" | "16vCPU-80GiB" | "16vCPU-88GiB" | "16vCPU-96GiB" | "16vCPU-104GiB" | "16vCPU-112GiB" | "16vCPU-120GiB";
+export type FargateTaskSizeOutputs = "0.25vCPU-512MiB" | "0.25vCPU-1GiB" | "0.25vCPU-2GiB" | "0.5vCPU-1GiB" | "0.5vCPU-2GiB" | "0.5vCPU-3GiB" | "0.5vCPU-4GiB" | "1vCPU-2GiB" | "1vCPU-3GiB" | "1vCPU-4GiB" | "1vCPU-5GiB" | "1vCPU-6GiB" | "1vCPU-7GiB" | "1vCPU-8GiB" | "2vCPU-4GiB" | "2vCPU-5GiB" | "2vCPU-6GiB" | "2vCPU-7GiB" | "2vCPU-8GiB" | "2vCPU-9GiB" | "2vCPU-10GiB" | "2vCPU-11GiB" | "2vCPU-12GiB" | "2vCPU-13GiB" | "2vCPU-14GiB" | "2vCPU-15GiB" | "2vCPU-16GiB" | "4vCPU-8GiB" | "4vCPU-9GiB" | "4vCPU-10GiB" | "4vCPU-11GiB" | "4vCPU-12GiB" | "4vCPU-13GiB" | "4vCPU-14GiB" | "4vCPU-15GiB" | "4vCPU-16GiB" | "4vCPU-17GiB" | "4vCPU-18GiB" | "4vCPU-19GiB" | "4vCPU-20GiB" | "4vCPU-21GiB" | "4vCPU-22GiB" | "4vCPU-23GiB" | "4vCPU-24GiB" | "4vCPU-25GiB" | "4vCPU-26GiB" | "4vCPU-27GiB" | "4vCPU-28GiB" | "4vCPU-29GiB" | "4vCPU-30GiB" | "8vCPU-16GiB" | "8vCPU-20GiB" | "8vCPU-24GiB" | "8vCPU-28GiB" | "8vCPU-32GiB" | "8vCPU-36GiB" | "8vCPU-40GiB" | "8vCPU-44GiB" | "8vCPU-48GiB" | "8vCPU-52GiB" | "8vCPU-56GiB" | "8vCPU-60GiB" | "16vCPU-32GiB" | "16vCPU-40GiB" | "16vCPU-48GiB" | "16vCPU-56GiB" | "16vCPU-64GiB" | "16vCPU-72GiB" | "16vCPU-80GiB" | "16vCPU-88GiB" | "16vCPU-96GiB" | "16vCPU-104GiB" | "16vCPU-112GiB" | "16vCPU-120GiB";
+export interface ServiceExecInputs {
+    readonly kmsKeyId?: pulumi.Input<string>;
+    readonly logGroup?: OptionalLogGroupInputs;
+    readonly s3Bucket?: DefaultBucketInputs;
+    readonly s3KeyPrefix?: pulumi.Input<string>;
+}
+export interface ServiceExecOutputs {
+    readonly kmsKeyId?: pulumi.Output<string>;
+    readonly logGroup?: OptionalLogGroupOutputs;
+    readonly s3Bucket?: DefaultBucketOutputs;
+    readonly s3KeyPrefix?: pulumi.Output<string>;
+}
+export interface ServiceFrontendInputs {
+    readonly containerName?: pulumi.Input<string>;
+    readonly containerPort: pulumi.Input<number>;
+    readonly healthCheck?: pulumi.Input<aws.types.input.lb.TargetGroupHealthCheck>;
+    readonly port?: pulumi.Input<number>;
+    readonly subnetType?: SubnetTypeInputs;
+}
+export interface ServiceFrontendOutputs {
+    readonly containerName?: pulumi.Output<string>;
+    readonly containerPort: pulumi.Output<number>;
+    readonly healthCheck?: pulumi.Output<aws.types.output.lb.TargetGroupHealthCheck>;
+    readonly port?: pulumi.Output<number>;
+    readonly subnetType?: SubnetTypeOutputs;
+}
+export interface ServiceLoadBalancerSecurityGroupInputs {
+    readonly egress?: boolean;
+    readonly securityGroupId: pulumi.Input<string>;
+}
+export interface ServiceLoadBalancerSecurityGroupOutputs {
+    readonly egress?: boolean;
+    readonly securityGroupId: pulumi.Output<string>;
+}
 export interface TaskDefinitionContainerDefinitionInputs {
     readonly command?: pulumi.Input<pulumi.Input<string>[]>;
     readonly cpu?: pulumi.Input<number>;
@@ -892,6 +1309,7 @@ export interface TaskDefinitionContainerDefinitionInputs {
     readonly readonlyRootFilesystem?: pulumi.Input<boolean>;
     readonly repositoryCredentials?: pulumi.Input<TaskDefinitionRepositoryCredentialsInputs>;
     readonly resourceRequirements?: pulumi.Input<pulumi.Input<TaskDefinitionResourceRequirementInputs>[]>;
+    readonly secretSources?: Record<string, TaskDefinitionSecretSourceInputs>;
     readonly secrets?: pulumi.Input<pulumi.Input<TaskDefinitionSecretInputs>[]>;
     readonly startTimeout?: pulumi.Input<number>;
     readonly stopTimeout?: pulumi.Input<number>;
@@ -933,6 +1351,7 @@ export interface TaskDefinitionContainerDefinitionOutputs {
     readonly readonlyRootFilesystem?: pulumi.Output<boolean>;
     readonly repositoryCredentials?: pulumi.Output<TaskDefinitionRepositoryCredentialsOutputs>;
     readonly resourceRequirements?: pulumi.Output<TaskDefinitionResourceRequirementOutputs[]>;
+    readonly secretSources?: Record<string, TaskDefinitionSecretSourceOutputs>;
     readonly secrets?: pulumi.Output<TaskDefinitionSecretOutputs[]>;
     readonly startTimeout?: pulumi.Output<number>;
     readonly stopTimeout?: pulumi.Output<number>;
@@ -960,6 +1379,24 @@ export interface TaskDefinitionDeviceOutputs {
     readonly hostPath?: pulumi.Output<string>;
     readonly permissions?: pulumi.Output<string[]>;
 }
+export interface TaskDefinitionEfsVolumeInputs {
+    readonly accessPointId?: pulumi.Input<string>;
+    readonly containerNames?: string[];
+    readonly containerPath?: pulumi.Input<string>;
+    readonly fileSystem: FileSystem;
+    readonly iamAuthorization?: boolean;
+    readonly readOnly?: boolean;
+    readonly rootDirectory?: pulumi.Input<string>;
+}
+export interface TaskDefinitionEfsVolumeOutputs {
+    readonly accessPointId?: pulumi.Output<string>;
+    readonly containerNames?: string[];
+    readonly containerPath?: pulumi.Output<string>;
+    readonly fileSystem: FileSystem;
+    readonly iamAuthorization?: boolean;
+    readonly readOnly?: boolean;
+    readonly rootDirectory?: pulumi.Output<string>;
+}
 export interface TaskDefinitionEnvironmentFileInputs {
     readonly type?: pulumi.Input<string>;
     readonly value?: pulumi.Input<string>;
@@ -1094,6 +1531,48 @@ export interface TaskDefinitionSecretOutputs {
     readonly name: pulumi.Output<string>;
     readonly valueFrom: pulumi.Output<string>;
 }
+export interface TaskDefinitionSecretSourceInputs {
+    readonly jsonKey?: pulumi.Input<string>;
+    readonly kmsKeyArn?: pulumi.Input<string>;
+    readonly parameterName?: pulumi.Input<string>;
+    readonly secretArn?: pulumi.Input<string>;
+    readonly versionId?: pulumi.Input<string>;
+    readonly versionStage?: pulumi.Input<string>;
+}
+export interface TaskDefinitionSecretSourceOutputs {
+    readonly jsonKey?: pulumi.Output<string>;
+    readonly kmsKeyArn?: pulumi.Output<string>;
+    readonly parameterName?: pulumi.Output<string>;
+    readonly secretArn?: pulumi.Output<string>;
+    readonly versionId?: pulumi.Output<string>;
+    readonly versionStage?: pulumi.Output<string>;
+}
+export interface TaskDefinitionSidecarInputs {
+    readonly cloudwatchAgentConfig?: pulumi.Input<string>;
+    readonly containerNames?: string[];
+    readonly cpu?: pulumi.Input<number>;
+    readonly datadogApiKeySecretArn?: pulumi.Input<string>;
+    readonly datadogSite?: pulumi.Input<string>;
+    readonly firelensOptions?: pulumi.Input<Record<string, pulumi.Input<string>>>;
+    readonly image?: pulumi.Input<string>;
+    readonly memory?: pulumi.Input<number>;
+    readonly name?: string;
+    readonly preset: TaskDefinitionSidecarPresetInputs;
+}
+export interface TaskDefinitionSidecarOutputs {
+    readonly cloudwatchAgentConfig?: pulumi.Output<string>;
+    readonly containerNames?: string[];
+    readonly cpu?: pulumi.Output<number>;
+    readonly datadogApiKeySecretArn?: pulumi.Output<string>;
+    readonly datadogSite?: pulumi.Output<string>;
+    readonly firelensOptions?: pulumi.Output<Record<string, string>>;
+    readonly image?: pulumi.Output<string>;
+    readonly memory?: pulumi.Output<number>;
+    readonly name?: string;
+    readonly preset: TaskDefinitionSidecarPresetOutputs;
+}
+export type TaskDefinitionSidecarPresetInputs = "FireLens" | "XRay" | "Datadog" | "CloudWatchAgent";
+export type TaskDefinitionSidecarPresetOutputs = "FireLens" | "XRay" | "Datadog" | "CloudWatchAgent";
 export interface TaskDefinitionSystemControlInputs {
     readonly namespace?: pulumi.Input<string>;
     readonly value?: pulumi.Input<string>;
@@ -1130,6 +1609,40 @@ export interface TaskDefinitionVolumeFromOutputs {
     readonly readOnly?: pulumi.Output<boolean>;
     readonly sourceContainer?: pulumi.Output<string>;
 }
+export interface AccessLogsInputs {
+    readonly args?: BucketInputs;
+    readonly existing?: ExistingBucketInputs;
+    readonly prefix?: pulumi.Input<string>;
+}
+export interface AccessLogsOutputs {
+    readonly args?: BucketOutputs;
+    readonly existing?: ExistingBucketOutputs;
+    readonly prefix?: pulumi.Output<string>;
+}
+export interface ApplicationLoadBalancerHttpsInputs {
+    readonly domainName: pulumi.Input<string>;
+    readonly rules?: ListenerRuleInputs[];
+    readonly sslPolicy?: pulumi.Input<string>;
+    readonly targetGroup?: string;
+    readonly zoneId: pulumi.Input<string>;
+}
+export interface ApplicationLoadBalancerHttpsOutputs {
+    readonly domainName: pulumi.Output<string>;
+    readonly rules?: ListenerRuleOutputs[];
+    readonly sslPolicy?: pulumi.Output<string>;
+    readonly targetGroup?: string;
+    readonly zoneId: pulumi.Output<string>;
+}
+export interface GatewayLoadBalancerEndpointInputs {
+    readonly destinationCidrBlock?: pulumi.Input<string>;
+    readonly routeTableIds?: pulumi.Input<string>[];
+    readonly subnetId: pulumi.Input<string>;
+}
+export interface GatewayLoadBalancerEndpointOutputs {
+    readonly destinationCidrBlock?: pulumi.Output<string>;
+    readonly routeTableIds?: string[];
+    readonly subnetId: pulumi.Output<string>;
+}
 export interface ListenerInputs {
     readonly alpnPolicy?: pulumi.Input<string>;
     readonly certificateArn?: pulumi.Input<string>;
@@ -1157,9 +1670,12 @@ export interface ListenerInputs {
     readonly routingHttpResponseStrictTransportSecurityHeaderValue?: pulumi.Input<string>;
     readonly routingHttpResponseXContentTypeOptionsHeaderValue?: pulumi.Input<string>;
     readonly routingHttpResponseXFrameOptionsHeaderValue?: pulumi.Input<string>;
+    readonly rules?: ListenerRuleInputs[];
     readonly sslPolicy?: pulumi.Input<string>;
     readonly tags?: pulumi.Input<Record<string, pulumi.Input<string>>>;
+    readonly targetGroup?: string;
     readonly tcpIdleTimeoutSeconds?: pulumi.Input<number>;
+    readonly tls?: ListenerTlsInputs;
 }
 export interface ListenerOutputs {
     readonly alpnPolicy?: pulumi.Output<string>;
@@ -1188,9 +1704,52 @@ export interface ListenerOutputs {
     readonly routingHttpResponseStrictTransportSecurityHeaderValue?: pulumi.Output<string>;
     readonly routingHttpResponseXContentTypeOptionsHeaderValue?: pulumi.Output<string>;
     readonly routingHttpResponseXFrameOptionsHeaderValue?: pulumi.Output<string>;
+    readonly rules?: ListenerRuleOutputs[];
     readonly sslPolicy?: pulumi.Output<string>;
     readonly tags?: pulumi.Output<Record<string, string>>;
+    readonly targetGroup?: string;
     readonly tcpIdleTimeoutSeconds?: pulumi.Output<number>;
+    readonly tls?: ListenerTlsOutputs;
+}
+export interface ListenerRuleInputs {
+    readonly action: ListenerRuleActionInputs;
+    readonly hostHeaders?: pulumi.Input<pulumi.Input<string>[]>;
+    readonly httpHeaders?: pulumi.Input<Record<string, pulumi.Input<pulumi.Input<string>[]>>>;
+    readonly pathPatterns?: pulumi.Input<pulumi.Input<string>[]>;
+    readonly priority?: pulumi.Input<number>;
+    readonly queryStrings?: pulumi.Input<Record<string, pulumi.Input<string>>>;
+}
+export interface ListenerRuleOutputs {
+    readonly action: ListenerRuleActionOutputs;
+    readonly hostHeaders?: pulumi.Output<string[]>;
+    readonly httpHeaders?: pulumi.Output<Record<string, string[]>>;
+    readonly pathPatterns?: pulumi.Output<string[]>;
+    readonly priority?: pulumi.Output<number>;
+    readonly queryStrings?: pulumi.Output<Record<string, string>>;
+}
+export interface ListenerRuleActionInputs {
+    readonly fixedResponse?: pulumi.Input<aws.types.input.lb.ListenerRuleActionFixedResponse>;
+    readonly redirect?: pulumi.Input<aws.types.input.lb.ListenerRuleActionRedirect>;
+    readonly targetGroup?: string;
+}
+export interface ListenerRuleActionOutputs {
+    readonly fixedResponse?: pulumi.Output<aws.types.output.lb.ListenerRuleActionFixedResponse>;
+    readonly redirect?: pulumi.Output<aws.types.output.lb.ListenerRuleActionRedirect>;
+    readonly targetGroup?: string;
+}
+export interface ListenerTlsInputs {
+    readonly alpnPolicy?: pulumi.Input<string>;
+    readonly certificateArn?: pulumi.Input<string>;
+    readonly domainName?: pulumi.Input<string>;
+    readonly sslPolicy?: pulumi.Input<string>;
+    readonly zoneId?: pulumi.Input<string>;
+}
+export interface ListenerTlsOutputs {
+    readonly alpnPolicy?: pulumi.Output<string>;
+    readonly certificateArn?: pulumi.Output<string>;
+    readonly domainName?: pulumi.Output<string>;
+    readonly sslPolicy?: pulumi.Output<string>;
+    readonly zoneId?: pulumi.Output<string>;
 }
 export interface TargetGroupInputs {
     readonly connectionTermination?: pulumi.Input<boolean>;
@@ -1246,6 +1805,16 @@ export interface TargetGroupOutputs {
     readonly targetType?: pulumi.Output<string>;
     readonly vpcId?: pulumi.Output<string>;
 }
+export type WebAclPresetInputs = "baseline" | "baseline-sql-database" | "baseline-linux";
+export type WebAclPresetOutputs = "baseline" | "baseline-sql-database" | "baseline-linux";
+export interface WeightedForwardInputs {
+    readonly stickinessDuration?: pulumi.Input<number>;
+    readonly weights: Record<string, pulumi.Input<number>>;
+}
+export interface WeightedForwardOutputs {
+    readonly stickinessDuration?: pulumi.Output<number>;
+    readonly weights: Record<string, number>;
+}
 export interface getDefaultVpcInputs {
 }
 export interface getDefaultVpcOutputs {
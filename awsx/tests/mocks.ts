@@ -0,0 +1,151 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import * as pulumi from "@pulumi/pulumi";
+
+// How long the program may go without registering a resource before lookups of resources that
+// weren't registered fail.
+const settleMs = 200;
+
+/**
+ * Records the resources registered with the mocks, so that tests can assert on the inputs of
+ * child resources. Call `recordResource` from the `newResource` mock.
+ */
+export function resourceRecorder() {
+  const recorded = new Map<string, pulumi.runtime.MockResourceArgs>();
+  const waiting = new Map<
+    string,
+    {
+      promise: Promise<pulumi.runtime.MockResourceArgs>;
+      resolve: (args: pulumi.runtime.MockResourceArgs) => void;
+      reject: (e: Error) => void;
+    }
+  >();
+  let settleTimer: NodeJS.Timeout | undefined;
+
+  // Fails the lookups still waiting once the program stopped registering resources, so that a
+  // missing resource fails its assertion instead of timing out the test.
+  function scheduleSettle() {
+    if (settleTimer !== undefined) {
+      clearTimeout(settleTimer);
+    }
+    if (waiting.size === 0) {
+      return;
+    }
+    settleTimer = setTimeout(() => {
+      for (const [key, { reject }] of waiting) {
+        const [type, name] = key.split("::");
+        reject(new Error(`No resource of type ${type} named ${name} was registered`));
+      }
+      waiting.clear();
+    }, settleMs);
+  }
+
+  // Resolves once the resource with the given type and name has been registered.
+  function registeredResource(
+    type: string,
+    name: string,
+  ): Promise<pulumi.runtime.MockResourceArgs> {
+    const key = `${type}::${name}`;
+    const resource = recorded.get(key);
+    if (resource !== undefined) {
+      return Promise.resolve(resource);
+    }
+    if (!waiting.has(key)) {
+      let resolve!: (args: pulumi.runtime.MockResourceArgs) => void;
+      let reject!: (e: Error) => void;
+      const promise = new Promise<pulumi.runtime.MockResourceArgs>((res, rej) => {
+        resolve = res;
+        reject = rej;
+      });
+      waiting.set(key, { promise, resolve, reject });
+      scheduleSettle();
+    }
+    return waiting.get(key)!.promise;
+  }
+
+  function recordResource(args: pulumi.runtime.MockResourceArgs) {
+    const key = `${args.type}::${args.name}`;
+    recorded.set(key, args);
+    waiting.get(key)?.resolve(args);
+    waiting.delete(key);
+    scheduleSettle();
+  }
+
+  return { recordResource, registeredResource };
+}
+
+export interface MockOverrides {
+  /** Returns state to add to the default state of a registered resource. */
+  state?: (args: pulumi.runtime.MockResourceArgs) => Record<string, any> | undefined;
+  /** Returns the result of a call, or undefined to use the default result. */
+  call?: (args: pulumi.runtime.MockCallArgs) => Record<string, any> | undefined;
+}
+
+/**
+ * The domain validation options ACM returns for a DNS-validated certificate.
+ */
+export function domainValidationOptions(domainName: string) {
+  return [
+    {
+      domainName,
+      resourceRecordName: `_validation.${domainName}`,
+      resourceRecordType: "CNAME",
+      resourceRecordValue: "_validation.acm-validations.aws",
+    },
+  ];
+}
+
+/**
+ * The result of the calls components commonly make. Other calls return their inputs.
+ */
+function defaultCall(args: pulumi.runtime.MockCallArgs): Record<string, any> {
+  switch (args.token) {
+    case "aws:ec2/getSubnet:getSubnet":
+      return { ...args.inputs, vpcId: "vpc-123" };
+    case "aws:index/getPartition:getPartition":
+      return { partition: "aws", dnsSuffix: "amazonaws.com" };
+    case "aws:index/getRegion:getRegion":
+      return { name: "us-west-2", region: "us-west-2" };
+    case "aws:index/getCallerIdentity:getCallerIdentity":
+      return { accountId: "123456789012" };
+    case "aws:lb/getTargetGroup:getTargetGroup":
+      return { ...args.inputs, loadBalancerArns: [] };
+    case "aws:lb/getLoadBalancer:getLoadBalancer":
+      return { ...args.inputs, securityGroups: [] };
+    default:
+      return args.inputs;
+  }
+}
+
+/**
+ * Sets up the mocks before the tests of the enclosing `describe` block and records the registered
+ * resources. Resources get their inputs and an `arn` derived from their name as state.
+ */
+export function mockResources(overrides: MockOverrides = {}) {
+  const recorder = resourceRecorder();
+  beforeAll(() => {
+    pulumi.runtime.setMocks({
+      newResource: (args: pulumi.runtime.MockResourceArgs): { id: string; state: any } => {
+        recorder.recordResource(args);
+        return {
+          id: `${args.name}-id`,
+          state: { ...args.inputs, arn: `arn:${args.name}`, ...overrides.state?.(args) },
+        };
+      },
+      call: (args: pulumi.runtime.MockCallArgs) => overrides.call?.(args) ?? defaultCall(args),
+    });
+  });
+  return recorder;
+}
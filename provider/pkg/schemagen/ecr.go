@@ -24,19 +24,52 @@ import (
 //go:embed docs/ecr/registry-image.md
 var registryImageDocs string
 
+//go:embed docs/ecr/image-copy.md
+var imageCopyDocs string
+
 func generateEcr(awsSpec, dockerSpec schema.PackageSpec) schema.PackageSpec {
 	return schema.PackageSpec{
 		Resources: map[string]schema.ResourceSpec{
 			"awsx:ecr:Repository":    repository(awsSpec),
 			"awsx:ecr:Image":         ecrImage(),
+			"awsx:ecr:ImageCopy":     imageCopy(),
 			"awsx:ecr:RegistryImage": registryImage(dockerSpec),
+			"awsx:ecr:Registry":      registry(awsSpec),
 		},
 		Types: map[string]schema.ComplexTypeSpec{
-			"awsx:ecr:DockerBuild":         dockerBuild(),
-			"awsx:ecr:BuilderVersion":      builderVersion(),
-			"awsx:ecr:lifecyclePolicy":     lifecyclePolicy(),
-			"awsx:ecr:lifecyclePolicyRule": lifecyclePolicyRule(),
-			"awsx:ecr:lifecycleTagStatus":  lifecycleTagStatus(),
+			"awsx:ecr:DockerBuild":              dockerBuild(),
+			"awsx:ecr:BuilderVersion":           builderVersion(),
+			"awsx:ecr:BuildCache":               buildCache(),
+			"awsx:ecr:BuildCacheMode":           buildCacheMode(),
+			"awsx:ecr:BuildSsh":                 buildSsh(),
+			"awsx:ecr:BuildSecret":              buildSecret(),
+			"awsx:ecr:lifecyclePolicy":          lifecyclePolicy(),
+			"awsx:ecr:lifecyclePolicyRule":      lifecyclePolicyRule(),
+			"awsx:ecr:lifecycleTagStatus":       lifecycleTagStatus(),
+			"awsx:ecr:lifecycleAgeUnit":         lifecycleAgeUnit(),
+			"awsx:ecr:lifecycleAgeSince":        lifecycleAgeSince(),
+			"awsx:ecr:lifecycleAction":          lifecycleAction(),
+			"awsx:ecr:lifecycleStorageClass":    lifecycleStorageClass(),
+			"awsx:ecr:RegistryReplication":      registryReplication(),
+			"awsx:ecr:ReplicationDestination":   replicationDestination(),
+			"awsx:ecr:PullThroughCacheRule":     pullThroughCacheRule(),
+			"awsx:ecr:PullThroughCacheUpstream": pullThroughCacheUpstream(),
+			"awsx:ecr:RegistryScanning":         registryScanning(),
+			"awsx:ecr:RegistryScanningRule":     registryScanningRule(),
+			"awsx:ecr:ScanType":                 scanType(),
+			"awsx:ecr:ScanFrequency":            scanFrequency(),
+			"awsx:ecr:RepositoryAccess":         repositoryAccess(),
+			"awsx:ecr:RepositoryAccessGrant":    repositoryAccessGrant(),
+			"awsx:ecr:RepositoryLambdaAccess":   repositoryLambdaAccess(),
+			"awsx:ecr:ScanGate":                 scanGate(),
+			"awsx:ecr:ScanSeverity":             scanSeverity(),
+			"awsx:ecr:ScanFindingsSummary":      scanFindingsSummary(),
+			"awsx:ecr:ImageSigning":             imageSigning(),
+			"awsx:ecr:ImageAttestations":        imageAttestations(),
+			"awsx:ecr:ImageCopySource":          imageCopySource(),
+			"awsx:ecr:RepositoryPreset":         repositoryPreset(),
+			"awsx:ecr:RepositoryNamingPolicy":   repositoryNamingPolicy(),
+			"awsx:ecr:RepositorySettings":       repositorySettings(),
 		},
 	}
 }
@@ -54,6 +87,38 @@ func repository(awsSpec schema.PackageSpec) schema.ResourceSpec {
 			Plain: true,
 		},
 	}
+	inputProperties["access"] = schema.PropertySpec{
+		Description: "Grants other accounts, organizations, principals or Lambda access to the " +
+			"repository. Creates a repository policy.",
+		TypeSpec: schema.TypeSpec{
+			Ref:   localRef("ecr", "RepositoryAccess"),
+			Plain: true,
+		},
+	}
+	inputProperties["preset"] = schema.PropertySpec{
+		Description: "Applies a standard set of settings to the repository. Settings that are " +
+			"provided explicitly take precedence over the preset.",
+		TypeSpec: schema.TypeSpec{
+			Ref:   localRef("ecr", "RepositoryPreset"),
+			Plain: true,
+		},
+	}
+	inputProperties["namingPolicy"] = schema.PropertySpec{
+		Description: "Validates the repository name before the repository is created. " +
+			"Requires [name], as generated names can't be validated.",
+		TypeSpec: schema.TypeSpec{
+			Ref:   localRef("ecr", "RepositoryNamingPolicy"),
+			Plain: true,
+		},
+	}
+	inputProperties["registry"] = schema.PropertySpec{
+		Description: "The registry the repository belongs to. Used to report the URLs the " +
+			"repository is replicated to.",
+		TypeSpec: schema.TypeSpec{
+			Ref:   "#/resources/awsx:ecr:Registry",
+			Plain: true,
+		},
+	}
 
 	return schema.ResourceSpec{
 		IsComponent:     true,
@@ -78,6 +143,30 @@ func repository(awsSpec schema.PackageSpec) schema.ResourceSpec {
 						}),
 					},
 				},
+				"repositoryPolicy": {
+					Description: "Repository policy rendered from [access]",
+					TypeSpec: schema.TypeSpec{
+						Ref: packageRef(awsSpec, "/resources/aws:ecr%2frepositoryPolicy:RepositoryPolicy"),
+					},
+				},
+				"replicaUrls": {
+					Description: "The URL of each replica of the repository, keyed like the " +
+						"`replicaRegistryUrls` of the [registry]. Empty if no [registry] is " +
+						"provided or the repository isn't replicated.",
+					TypeSpec: schema.TypeSpec{
+						Type: "object",
+						AdditionalProperties: &schema.TypeSpec{
+							Type: "string",
+						},
+					},
+				},
+				"settings": {
+					Description: "The settings the repository was created with, after applying " +
+						"the [preset]. Export them to make them available to audits.",
+					TypeSpec: schema.TypeSpec{
+						Ref: localRef("ecr", "RepositorySettings"),
+					},
+				},
 				"url": {
 					Description: "The URL of the repository (in the form aws_account_id.dkr." +
 						"ecr.region.amazonaws.com/repositoryName).\n",
@@ -92,7 +181,7 @@ func repository(awsSpec schema.PackageSpec) schema.ResourceSpec {
 					},
 				},
 			},
-			Required: []string{"repository", "url"},
+			Required: []string{"repository", "settings", "url"},
 		},
 	}
 }
@@ -108,9 +197,9 @@ func lifecyclePolicy() schema.ComplexTypeSpec {
 			Properties: map[string]schema.PropertySpec{
 				"rules": {
 					Description: "Specifies the rules to determine how images should be " +
-						"retired from this repository. Rules are ordered from lowest " +
-						"priority to highest.  If there is a rule with a `selection` value " +
-						"of `any`, then it will have the highest priority.",
+						"retired from this repository. Rules without a `priority` are ordered " +
+						"from lowest priority to highest.  If there is a rule with a `selection` " +
+						"value of `any`, then it will have the highest priority.",
 					TypeSpec: schema.TypeSpec{
 						Type: "array",
 						Items: &schema.TypeSpec{
@@ -151,12 +240,45 @@ func lifecyclePolicyRule() schema.ComplexTypeSpec {
 					},
 				},
 				"maximumAgeLimit": {
-					Description: "The maximum age limit (in days) for your images. Either " +
-						"[maximumNumberOfImages] or [maximumAgeLimit] must be provided.",
+					Description: "The maximum age limit (in days, unless [maximumAgeUnit] is set) " +
+						"for your images. Either [maximumNumberOfImages] or [maximumAgeLimit] " +
+						"must be provided.",
 					TypeSpec: schema.TypeSpec{
 						Type: "number",
 					},
 				},
+				"maximumAgeUnit": {
+					Description: "The unit of [maximumAgeLimit]. Defaults to `days`.",
+					TypeSpec: schema.TypeSpec{
+						Ref: "#/types/awsx:ecr:lifecycleAgeUnit",
+					},
+				},
+				"maximumAgeSince": {
+					Description: "The event [maximumAgeLimit] is counted from. Defaults to `imagePushed`.",
+					TypeSpec: schema.TypeSpec{
+						Ref: "#/types/awsx:ecr:lifecycleAgeSince",
+					},
+				},
+				"priority": {
+					Description: "The priority of the rule. Rules are evaluated from the lowest " +
+						"priority to the highest and each priority must be unique. Rules without " +
+						"a priority are given the lowest free priorities in list order.",
+					TypeSpec: schema.TypeSpec{
+						Type: "integer",
+					},
+				},
+				"action": {
+					Description: "What happens to the selected images. Defaults to `expire`.",
+					TypeSpec: schema.TypeSpec{
+						Ref: "#/types/awsx:ecr:lifecycleAction",
+					},
+				},
+				"storageClass": {
+					Description: "Only select images in the given storage class. Defaults to `standard`.",
+					TypeSpec: schema.TypeSpec{
+						Ref: "#/types/awsx:ecr:lifecycleStorageClass",
+					},
+				},
 				"tagStatus": {
 					Description: "Determines whether the lifecycle policy rule that you are " +
 						"adding specifies a tag for an image. Acceptable options are " +
@@ -182,6 +304,19 @@ func lifecyclePolicyRule() schema.ComplexTypeSpec {
 						},
 					},
 				},
+				"tagPatternList": {
+					Description: "A list of image tag patterns on which to take action with your " +
+						"lifecycle policy. Only used if you specified \"tagStatus\": \"tagged\". " +
+						"Each pattern can contain up to four `*` wildcards, e.g. `prod*` or " +
+						"`*-release-*`. If you specify multiple patterns, only the images " +
+						"matching all of them are selected. Cannot be combined with [tagPrefixList].",
+					TypeSpec: schema.TypeSpec{
+						Type: "array",
+						Items: &schema.TypeSpec{
+							Type: "string",
+						},
+					},
+				},
 			},
 			Required: []string{"tagStatus"},
 		},
@@ -228,6 +363,52 @@ func ecrImage() schema.ResourceSpec {
 			Type: "string",
 		},
 	}
+	inputs["scanGate"] = schema.PropertySpec{
+		Description: "Waits for the scan of each pushed image digest and fails the update if " +
+			"it found vulnerabilities at or above a severity. The gate only runs when the " +
+			"digest changes. The repository or registry must scan images on push, and the " +
+			"scans are read with the credentials of the `aws` provider configuration.",
+		TypeSpec: schema.TypeSpec{
+			Ref: localRef("ecr", "ScanGate"),
+		},
+	}
+	inputs["signing"] = schema.PropertySpec{
+		Description: "Signs the pushed image. Signing runs the `notation` or `cosign` CLI, " +
+			"which must be installed where the update runs.",
+		TypeSpec: schema.TypeSpec{
+			Ref: localRef("ecr", "ImageSigning"),
+		},
+	}
+	inputs["attestations"] = schema.PropertySpec{
+		Description: "Has BuildKit attach SBOM and provenance attestations to the pushed image. " +
+			"The image is then pushed as an index holding the image and its attestations.",
+		TypeSpec: schema.TypeSpec{
+			Ref: localRef("ecr", "ImageAttestations"),
+		},
+	}
+	inputs["tags"] = schema.PropertySpec{
+		Description: "Additional tags to push the image with, e.g. a git SHA, a version or " +
+			"`latest`. If neither `imageName` nor `imageTag` is set, the first tag also names " +
+			"the image.",
+		TypeSpec: schema.TypeSpec{
+			Type: "array",
+			Items: &schema.TypeSpec{
+				Type: "string",
+			},
+		},
+	}
+	inputs["checkImmutableTags"] = schema.PropertySpec{
+		Description: "Fail with an error naming the conflicting tags, instead of a failed push, " +
+			"if the repository has immutable tags and one of the tags already points to another " +
+			"image. The image is first pushed by digest without tags so that its digest can be " +
+			"compared with the existing tags, and then rebuilt from the build cache and pushed " +
+			"with its tags. Requires the `ecr:DescribeRepositories` and `ecr:ListImages` " +
+			"permissions. Only one of [platforms] or [checkImmutableTags] can be specified.",
+		TypeSpec: schema.TypeSpec{
+			Type:  "boolean",
+			Plain: true,
+		},
+	}
 	return schema.ResourceSpec{
 		IsComponent:     true,
 		InputProperties: inputs,
@@ -242,8 +423,71 @@ func ecrImage() schema.ResourceSpec {
 						Type: "string",
 					},
 				},
+				"digest": {
+					Description: "Digest of the pushed image. When building for multiple " +
+						"`platforms` this is the digest of the manifest list.",
+					TypeSpec: schema.TypeSpec{
+						Type: "string",
+					},
+				},
+				"tags": {
+					Description: "All tags the image was pushed with.",
+					TypeSpec: schema.TypeSpec{
+						Type: "array",
+						Items: &schema.TypeSpec{
+							Type: "string",
+						},
+					},
+				},
+				"taggedUris": {
+					Description: "The image URI for each of the `tags`, in the form `repositoryUrl:tag`.",
+					TypeSpec: schema.TypeSpec{
+						Type: "array",
+						Items: &schema.TypeSpec{
+							Type: "string",
+						},
+					},
+				},
+				"scanFindings": {
+					Description: "Summary of the scan findings of the image. Only set if " +
+						"[scanGate] is provided.",
+					TypeSpec: schema.TypeSpec{
+						Ref: localRef("ecr", "ScanFindingsSummary"),
+					},
+				},
+				"signatureRefs": {
+					Description: "References to the signatures of the image. Only set if " +
+						"[signing] is provided.",
+					TypeSpec: schema.TypeSpec{
+						Type: "array",
+						Items: &schema.TypeSpec{
+							Type: "string",
+						},
+					},
+				},
+				"attestationRefs": {
+					Description: "References to the attestation manifests pushed in the index of " +
+						"each platform image. Only set if [attestations] is provided. The " +
+						"deployment fails if a requested attestation is missing.",
+					TypeSpec: schema.TypeSpec{
+						Type: "array",
+						Items: &schema.TypeSpec{
+							Type: "string",
+						},
+					},
+				},
+				"platformDigests": {
+					Description: "Digest of the image pushed for each platform, keyed by platform. " +
+						"Use these to pin a task definition to a single architecture.",
+					TypeSpec: schema.TypeSpec{
+						Type: "object",
+						AdditionalProperties: &schema.TypeSpec{
+							Type: "string",
+						},
+					},
+				},
 			},
-			Required: []string{"imageUri"},
+			Required: []string{"imageUri", "tags", "taggedUris"},
 		},
 	}
 }
@@ -277,6 +521,113 @@ func builderVersion() schema.ComplexTypeSpec {
 	}
 }
 
+func buildCache() schema.ComplexTypeSpec {
+	return schema.ComplexTypeSpec{
+		ObjectTypeSpec: schema.ObjectTypeSpec{
+			Type: "object",
+			Description: "A BuildKit cache stored as an image in an ECR repository. The cache " +
+				"is imported before the build and exported after it.",
+			Properties: map[string]schema.PropertySpec{
+				"repositoryUrl": {
+					Description: "Url of the repository to store the cache in. Defaults to the " +
+						"repository the image is pushed to. Use a sibling repository to keep " +
+						"cache layers out of the image repository.",
+					TypeSpec: schema.TypeSpec{
+						Type: "string",
+					},
+				},
+				"tag": {
+					Description: "Tag of the cache image. Defaults to `buildcache`.",
+					TypeSpec: schema.TypeSpec{
+						Type: "string",
+					},
+				},
+				"mode": {
+					Description: "Which layers to export to the cache. Defaults to `max`.",
+					TypeSpec: schema.TypeSpec{
+						Ref: localRef("ecr", "BuildCacheMode"),
+					},
+				},
+			},
+		},
+	}
+}
+
+func buildCacheMode() schema.ComplexTypeSpec {
+	return schema.ComplexTypeSpec{
+		ObjectTypeSpec: schema.ObjectTypeSpec{
+			Type:        "string",
+			Description: "The layers exported to a build cache",
+		},
+		Enum: []schema.EnumValueSpec{
+			{
+				Value:       "min",
+				Description: "Only export the layers of the resulting image.",
+			},
+			{
+				Value:       "max",
+				Description: "Export the layers of all intermediate build steps.",
+			},
+		},
+	}
+}
+
+func buildSsh() schema.ComplexTypeSpec {
+	return schema.ComplexTypeSpec{
+		ObjectTypeSpec: schema.ObjectTypeSpec{
+			Type:        "object",
+			Description: "An SSH agent socket or keys to expose to the build",
+			Properties: map[string]schema.PropertySpec{
+				"id": {
+					Description: "Identifier used by `RUN --mount=type=ssh,id=...` in the " +
+						"Dockerfile. Use `default` to match mounts without an identifier.",
+					TypeSpec: schema.TypeSpec{
+						Type: "string",
+					},
+				},
+				"paths": {
+					Description: "Paths to an SSH agent socket or private keys. Defaults to " +
+						"the agent socket in `SSH_AUTH_SOCK`.",
+					TypeSpec: schema.TypeSpec{
+						Type: "array",
+						Items: &schema.TypeSpec{
+							Type: "string",
+						},
+					},
+				},
+			},
+			Required: []string{"id"},
+		},
+	}
+}
+
+func buildSecret() schema.ComplexTypeSpec {
+	return schema.ComplexTypeSpec{
+		ObjectTypeSpec: schema.ObjectTypeSpec{
+			Type: "object",
+			Description: "A secret to expose to the build, given by reference to the environment " +
+				"variable or file its value is read from when the image is built. Exactly one of " +
+				"[env] or [src] must be specified.",
+			Properties: map[string]schema.PropertySpec{
+				"env": {
+					Description: "Name of the environment variable holding the secret value.",
+					TypeSpec: schema.TypeSpec{
+						Type:  "string",
+						Plain: true,
+					},
+				},
+				"src": {
+					Description: "Path to the file holding the secret value.",
+					TypeSpec: schema.TypeSpec{
+						Type:  "string",
+						Plain: true,
+					},
+				},
+			},
+		},
+	}
+}
+
 func dockerBuildProperties() map[string]schema.PropertySpec {
 	return map[string]schema.PropertySpec{
 		"args": {
@@ -298,6 +649,13 @@ func dockerBuildProperties() map[string]schema.PropertySpec {
 				Plain: true,
 			},
 		},
+		"cache": {
+			Description: "A registry cache to import before and export after the build. " +
+				"Without it the image is built with an inline cache.",
+			TypeSpec: schema.TypeSpec{
+				Ref: localRef("ecr", "BuildCache"),
+			},
+		},
 		"cacheFrom": {
 			Description: "Images to consider as cache sources",
 			TypeSpec: schema.TypeSpec{
@@ -346,6 +704,40 @@ func dockerBuildProperties() map[string]schema.PropertySpec {
 				Type: "string",
 			},
 		},
+		"platforms": {
+			Description: "The platforms to build this image for, e.g. `[\"linux/amd64\", \"linux/arm64\"]`. " +
+				"Each platform is built separately and the results are pushed as a single " +
+				"multi-platform manifest list. Cannot be combined with `platform`.",
+			TypeSpec: schema.TypeSpec{
+				Type: "array",
+				Items: &schema.TypeSpec{
+					Type: "string",
+				},
+			},
+		},
+		"secrets": {
+			Description: "Secrets to expose to the build, keyed by the identifier used by " +
+				"`RUN --mount=type=secret,id=...` in the Dockerfile. Secrets are given by " +
+				"reference to an environment variable or file, like `--secret id=...,env=...` " +
+				"of `docker buildx build`, and are not persisted in the image or its name.",
+			TypeSpec: schema.TypeSpec{
+				Type:  "object",
+				Plain: true,
+				AdditionalProperties: &schema.TypeSpec{
+					Ref:   localRef("ecr", "BuildSecret"),
+					Plain: true,
+				},
+			},
+		},
+		"ssh": {
+			Description: "SSH agent sockets or keys to expose to the build.",
+			TypeSpec: schema.TypeSpec{
+				Type: "array",
+				Items: &schema.TypeSpec{
+					Ref: localRef("ecr", "BuildSsh"),
+				},
+			},
+		},
 		"target": {
 			Description: "The target of the dockerfile to build",
 			TypeSpec: schema.TypeSpec{
@@ -399,3 +791,826 @@ func registryImage(dockerSpec schema.PackageSpec) schema.ResourceSpec {
 		},
 	}
 }
+
+func imageCopy() schema.ResourceSpec {
+	return schema.ResourceSpec{
+		IsComponent: true,
+		InputProperties: map[string]schema.PropertySpec{
+			"repositoryUrl": {
+				Description: "The URL of the repository to copy the image to (in the form " +
+					"aws_account_id.dkr.ecr.region.amazonaws.com/repositoryName).",
+				TypeSpec: schema.TypeSpec{
+					Type: "string",
+				},
+			},
+			"source": {
+				Description: "The image to copy.",
+				TypeSpec: schema.TypeSpec{
+					Ref: localRef("ecr", "ImageCopySource"),
+				},
+			},
+			"tag": {
+				Description: "The tag to use for the copied image. If not provided, it defaults to `latest`.",
+				TypeSpec: schema.TypeSpec{
+					Type: "string",
+				},
+			},
+		},
+		RequiredInputs: []string{"repositoryUrl", "source"},
+		ObjectTypeSpec: schema.ObjectTypeSpec{
+			Type:        "object",
+			Description: imageCopyDocs,
+			Properties: map[string]schema.PropertySpec{
+				"digest": {
+					Description: "The digest of the copied image, which is the digest of the source image.",
+					TypeSpec: schema.TypeSpec{
+						Type: "string",
+					},
+				},
+				"imageUri": {
+					Description: "The unique URI of the copied image, pinned by digest.",
+					TypeSpec: schema.TypeSpec{
+						Type: "string",
+					},
+				},
+			},
+			Required: []string{"digest", "imageUri"},
+		},
+	}
+}
+
+func lifecycleAgeUnit() schema.ComplexTypeSpec {
+	return schema.ComplexTypeSpec{
+		ObjectTypeSpec: schema.ObjectTypeSpec{
+			Type: "string",
+		},
+		Enum: []schema.EnumValueSpec{
+			{
+				Name:        "days",
+				Description: "The age is given in days",
+				Value:       "days",
+			},
+			{
+				Name:        "weeks",
+				Description: "The age is given in weeks. ECR counts in days, so the age is converted to days",
+				Value:       "weeks",
+			},
+		},
+	}
+}
+
+func lifecycleAgeSince() schema.ComplexTypeSpec {
+	return schema.ComplexTypeSpec{
+		ObjectTypeSpec: schema.ObjectTypeSpec{
+			Type: "string",
+		},
+		Enum: []schema.EnumValueSpec{
+			{
+				Name:        "imagePushed",
+				Description: "Count the age from when the image was pushed",
+				Value:       "imagePushed",
+			},
+			{
+				Name:        "imagePulled",
+				Description: "Count the age from when the image was last pulled",
+				Value:       "imagePulled",
+			},
+			{
+				Name:        "imageTransitioned",
+				Description: "Count the age from when the image was archived. Requires [storageClass] `archive`",
+				Value:       "imageTransitioned",
+			},
+		},
+	}
+}
+
+func lifecycleAction() schema.ComplexTypeSpec {
+	return schema.ComplexTypeSpec{
+		ObjectTypeSpec: schema.ObjectTypeSpec{
+			Type: "string",
+		},
+		Enum: []schema.EnumValueSpec{
+			{
+				Name:        "expire",
+				Description: "Delete the selected images",
+				Value:       "expire",
+			},
+			{
+				Name:        "archive",
+				Description: "Move the selected images to the archive storage class",
+				Value:       "archive",
+			},
+		},
+	}
+}
+
+func lifecycleStorageClass() schema.ComplexTypeSpec {
+	return schema.ComplexTypeSpec{
+		ObjectTypeSpec: schema.ObjectTypeSpec{
+			Type: "string",
+		},
+		Enum: []schema.EnumValueSpec{
+			{
+				Name:        "standard",
+				Description: "Images that can be pulled",
+				Value:       "standard",
+			},
+			{
+				Name:        "archive",
+				Description: "Archived images, which have to be restored before they can be pulled",
+				Value:       "archive",
+			},
+		},
+	}
+}
+
+func registry(awsSpec schema.PackageSpec) schema.ResourceSpec {
+	return schema.ResourceSpec{
+		IsComponent: true,
+		ObjectTypeSpec: schema.ObjectTypeSpec{
+			Type: "object",
+			Description: "Manages the registry-wide settings of the ECR registry of the current " +
+				"account: replication to other regions and accounts, pull-through cache rules " +
+				"for upstream registries and image scanning.",
+			Properties: map[string]schema.PropertySpec{
+				"registryId": {
+					Description: "ID of the registry, which is the ID of the account.",
+					TypeSpec: schema.TypeSpec{
+						Type: "string",
+					},
+				},
+				"replicationConfiguration": {
+					Description: "Replication configuration of the registry",
+					TypeSpec:    awsResource(awsSpec, "aws:ecr/replicationConfiguration:ReplicationConfiguration"),
+				},
+				"replicaRegistryUrls": {
+					Description: "URL of each registry images are replicated to, keyed by region. " +
+						"Registries in other accounts are keyed by `<registryId>/<region>`.",
+					TypeSpec: schema.TypeSpec{
+						Type: "object",
+						AdditionalProperties: &schema.TypeSpec{
+							Type: "string",
+						},
+					},
+				},
+				"replicatedRepositoryPrefixes": {
+					Description: "Prefixes of the names of the repositories that are replicated. " +
+						"Empty if all repositories are replicated.",
+					TypeSpec: schema.TypeSpec{
+						Type: "array",
+						Items: &schema.TypeSpec{
+							Type: "string",
+						},
+					},
+				},
+				"pullThroughCacheRules": {
+					Description: "Pull-through cache rules, keyed by repository prefix",
+					TypeSpec: schema.TypeSpec{
+						Type: "object",
+						AdditionalProperties: &schema.TypeSpec{
+							Ref: packageRef(awsSpec, "/resources/aws:ecr%2fpullThroughCacheRule:PullThroughCacheRule"),
+						},
+					},
+				},
+				"scanningConfiguration": {
+					Description: "Scanning configuration of the registry",
+					TypeSpec:    awsResource(awsSpec, "aws:ecr/registryScanningConfiguration:RegistryScanningConfiguration"),
+				},
+			},
+			Required: []string{"registryId", "replicaRegistryUrls", "replicatedRepositoryPrefixes"},
+		},
+		InputProperties: map[string]schema.PropertySpec{
+			"replication": {
+				Description: "Replicates the images pushed to the registry to other regions or accounts.",
+				TypeSpec: schema.TypeSpec{
+					Ref:   localRef("ecr", "RegistryReplication"),
+					Plain: true,
+				},
+			},
+			"pullThroughCacheRules": {
+				Description: "Pull-through cache rules, keyed by the repository prefix that " +
+					"images of the upstream registry are pulled through, e.g. `docker-hub`.",
+				TypeSpec: schema.TypeSpec{
+					Type: "object",
+					AdditionalProperties: &schema.TypeSpec{
+						Ref:   localRef("ecr", "PullThroughCacheRule"),
+						Plain: true,
+					},
+					Plain: true,
+				},
+			},
+			"scanning": {
+				Description: "Configures how images pushed to the registry are scanned.",
+				TypeSpec: schema.TypeSpec{
+					Ref:   localRef("ecr", "RegistryScanning"),
+					Plain: true,
+				},
+			},
+			"region": {
+				Description: "Region where the resources are managed. Defaults to the region set in " +
+					"the provider configuration.",
+				TypeSpec: schema.TypeSpec{
+					Type: "string",
+				},
+			},
+		},
+	}
+}
+
+func registryReplication() schema.ComplexTypeSpec {
+	return schema.ComplexTypeSpec{
+		ObjectTypeSpec: schema.ObjectTypeSpec{
+			Type:        "object",
+			Description: "Replication of the images of a registry",
+			Properties: map[string]schema.PropertySpec{
+				"destinations": {
+					Description: "Registries to replicate the images to. The source registry itself " +
+						"can't be a destination.",
+					TypeSpec: schema.TypeSpec{
+						Type: "array",
+						Items: &schema.TypeSpec{
+							Ref:   localRef("ecr", "ReplicationDestination"),
+							Plain: true,
+						},
+						Plain: true,
+					},
+				},
+				"repositoryPrefixes": {
+					Description: "Only replicate the repositories whose names start with one of " +
+						"the prefixes. Defaults to replicating all repositories.",
+					TypeSpec: schema.TypeSpec{
+						Type: "array",
+						Items: &schema.TypeSpec{
+							Type:  "string",
+							Plain: true,
+						},
+						Plain: true,
+					},
+				},
+			},
+			Required: []string{"destinations"},
+		},
+	}
+}
+
+func replicationDestination() schema.ComplexTypeSpec {
+	return schema.ComplexTypeSpec{
+		ObjectTypeSpec: schema.ObjectTypeSpec{
+			Type:        "object",
+			Description: "A registry images are replicated to",
+			Properties: map[string]schema.PropertySpec{
+				"region": {
+					Description: "Region of the registry.",
+					TypeSpec: schema.TypeSpec{
+						Type:  "string",
+						Plain: true,
+					},
+				},
+				"registryId": {
+					Description: "ID of the account of the registry. Defaults to the current account.",
+					TypeSpec: schema.TypeSpec{
+						Type:  "string",
+						Plain: true,
+					},
+				},
+			},
+			Required: []string{"region"},
+		},
+	}
+}
+
+func pullThroughCacheRule() schema.ComplexTypeSpec {
+	return schema.ComplexTypeSpec{
+		ObjectTypeSpec: schema.ObjectTypeSpec{
+			Type: "object",
+			Description: "Caches the images of an upstream registry in repositories of the " +
+				"registry. Docker Hub and the GitHub Container Registry require credentials, " +
+				"either as an existing secret or as a username and access token.",
+			Properties: map[string]schema.PropertySpec{
+				"upstream": {
+					Description: "The upstream registry.",
+					TypeSpec: schema.TypeSpec{
+						Ref:   localRef("ecr", "PullThroughCacheUpstream"),
+						Plain: true,
+					},
+				},
+				"credentialArn": {
+					Description: "ARN of an existing Secrets Manager secret holding the credentials " +
+						"of the upstream registry. The name of the secret must start with " +
+						"`ecr-pullthroughcache/`.",
+					TypeSpec: schema.TypeSpec{
+						Type: "string",
+					},
+				},
+				"username": {
+					Description: "Username for the upstream registry. A secret holding the " +
+						"username and [accessToken] is created.",
+					TypeSpec: schema.TypeSpec{
+						Type: "string",
+					},
+				},
+				"accessToken": {
+					Description: "Access token for the upstream registry.",
+					TypeSpec: schema.TypeSpec{
+						Type: "string",
+					},
+					Secret: true,
+				},
+			},
+			Required: []string{"upstream"},
+		},
+	}
+}
+
+func pullThroughCacheUpstream() schema.ComplexTypeSpec {
+	return schema.ComplexTypeSpec{
+		ObjectTypeSpec: schema.ObjectTypeSpec{
+			Type: "string",
+		},
+		Enum: []schema.EnumValueSpec{
+			{
+				Name:        "DockerHub",
+				Description: "Docker Hub (registry-1.docker.io)",
+				Value:       "docker-hub",
+			},
+			{
+				Name:        "GitHubContainerRegistry",
+				Description: "GitHub Container Registry (ghcr.io)",
+				Value:       "github-container-registry",
+			},
+			{
+				Name:        "Quay",
+				Description: "Quay (quay.io)",
+				Value:       "quay",
+			},
+		},
+	}
+}
+
+func registryScanning() schema.ComplexTypeSpec {
+	return schema.ComplexTypeSpec{
+		ObjectTypeSpec: schema.ObjectTypeSpec{
+			Type:        "object",
+			Description: "Scanning of the images pushed to a registry",
+			Properties: map[string]schema.PropertySpec{
+				"scanType": {
+					Description: "The type of scanning. Defaults to `ENHANCED`.",
+					TypeSpec: schema.TypeSpec{
+						Ref: localRef("ecr", "ScanType"),
+					},
+				},
+				"rules": {
+					Description: "Rules selecting how often the repositories are scanned. " +
+						"Defaults to scanning all repositories on push.",
+					TypeSpec: schema.TypeSpec{
+						Type: "array",
+						Items: &schema.TypeSpec{
+							Ref: localRef("ecr", "RegistryScanningRule"),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func registryScanningRule() schema.ComplexTypeSpec {
+	return schema.ComplexTypeSpec{
+		ObjectTypeSpec: schema.ObjectTypeSpec{
+			Type:        "object",
+			Description: "How often the selected repositories are scanned",
+			Properties: map[string]schema.PropertySpec{
+				"frequency": {
+					Description: "How often the repositories are scanned.",
+					TypeSpec: schema.TypeSpec{
+						Ref: localRef("ecr", "ScanFrequency"),
+					},
+				},
+				"repositoryFilters": {
+					Description: "Names of the repositories the rule applies to. Names can " +
+						"contain `*` wildcards. Defaults to all repositories.",
+					TypeSpec: schema.TypeSpec{
+						Type: "array",
+						Items: &schema.TypeSpec{
+							Type: "string",
+						},
+					},
+				},
+			},
+			Required: []string{"frequency"},
+		},
+	}
+}
+
+func scanType() schema.ComplexTypeSpec {
+	return schema.ComplexTypeSpec{
+		ObjectTypeSpec: schema.ObjectTypeSpec{
+			Type: "string",
+		},
+		Enum: []schema.EnumValueSpec{
+			{
+				Name:        "Basic",
+				Description: "Scan for operating system vulnerabilities",
+				Value:       "BASIC",
+			},
+			{
+				Name:        "Enhanced",
+				Description: "Scan for operating system and programming language vulnerabilities with Amazon Inspector",
+				Value:       "ENHANCED",
+			},
+		},
+	}
+}
+
+func scanFrequency() schema.ComplexTypeSpec {
+	return schema.ComplexTypeSpec{
+		ObjectTypeSpec: schema.ObjectTypeSpec{
+			Type: "string",
+		},
+		Enum: []schema.EnumValueSpec{
+			{
+				Name:        "ScanOnPush",
+				Description: "Scan images when they are pushed",
+				Value:       "SCAN_ON_PUSH",
+			},
+			{
+				Name:        "ContinuousScan",
+				Description: "Scan images when they are pushed and when new vulnerabilities are published",
+				Value:       "CONTINUOUS_SCAN",
+			},
+			{
+				Name:        "Manual",
+				Description: "Only scan images when a scan is started manually",
+				Value:       "MANUAL",
+			},
+		},
+	}
+}
+
+func repositoryAccess() schema.ComplexTypeSpec {
+	return schema.ComplexTypeSpec{
+		ObjectTypeSpec: schema.ObjectTypeSpec{
+			Type:        "object",
+			Description: "Access to a repository granted by its repository policy",
+			Properties: map[string]schema.PropertySpec{
+				"pull": {
+					Description: "Who can pull images from the repository.",
+					TypeSpec: schema.TypeSpec{
+						Ref:   localRef("ecr", "RepositoryAccessGrant"),
+						Plain: true,
+					},
+				},
+				"push": {
+					Description: "Who can push images to the repository. Pushing doesn't " +
+						"include pulling, grant [pull] as well if needed.",
+					TypeSpec: schema.TypeSpec{
+						Ref:   localRef("ecr", "RepositoryAccessGrant"),
+						Plain: true,
+					},
+				},
+				"lambda": {
+					Description: "Lets Lambda pull images of the repository for functions " +
+						"in other accounts.",
+					TypeSpec: schema.TypeSpec{
+						Ref:   localRef("ecr", "RepositoryLambdaAccess"),
+						Plain: true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func repositoryAccessGrant() schema.ComplexTypeSpec {
+	return schema.ComplexTypeSpec{
+		ObjectTypeSpec: schema.ObjectTypeSpec{
+			Type:        "object",
+			Description: "The principals access to a repository is granted to",
+			Properties: map[string]schema.PropertySpec{
+				"accountIds": {
+					Description: "IDs of the accounts whose principals are granted access.",
+					TypeSpec: schema.TypeSpec{
+						Type: "array",
+						Items: &schema.TypeSpec{
+							Type: "string",
+						},
+					},
+				},
+				"organizationIds": {
+					Description: "IDs of the AWS Organizations whose principals are granted access.",
+					TypeSpec: schema.TypeSpec{
+						Type: "array",
+						Items: &schema.TypeSpec{
+							Type: "string",
+						},
+					},
+				},
+				"principals": {
+					Description: "ARNs of the IAM roles or users granted access.",
+					TypeSpec: schema.TypeSpec{
+						Type: "array",
+						Items: &schema.TypeSpec{
+							Type: "string",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func repositoryLambdaAccess() schema.ComplexTypeSpec {
+	return schema.ComplexTypeSpec{
+		ObjectTypeSpec: schema.ObjectTypeSpec{
+			Type:        "object",
+			Description: "Access of the Lambda service to a repository on behalf of functions",
+			Properties: map[string]schema.PropertySpec{
+				"sourceArns": {
+					Description: "ARNs of the functions that can use images of the repository. " +
+						"ARNs can contain `*` wildcards, e.g. " +
+						"`arn:aws:lambda:us-east-1:123456789012:function:*`.",
+					TypeSpec: schema.TypeSpec{
+						Type: "array",
+						Items: &schema.TypeSpec{
+							Type: "string",
+						},
+					},
+				},
+			},
+			Required: []string{"sourceArns"},
+		},
+	}
+}
+
+func scanGate() schema.ComplexTypeSpec {
+	return schema.ComplexTypeSpec{
+		ObjectTypeSpec: schema.ObjectTypeSpec{
+			Type:        "object",
+			Description: "Fails the update if the scan of an image found vulnerabilities",
+			Properties: map[string]schema.PropertySpec{
+				"severityThreshold": {
+					Description: "Findings of this severity or higher fail the update. " +
+						"Defaults to `HIGH`.",
+					TypeSpec: schema.TypeSpec{
+						Ref: localRef("ecr", "ScanSeverity"),
+					},
+				},
+				"allowedVulnerabilities": {
+					Description: "IDs of vulnerabilities that never fail the update, e.g. " +
+						"`CVE-2023-12345`.",
+					TypeSpec: schema.TypeSpec{
+						Type: "array",
+						Items: &schema.TypeSpec{
+							Type: "string",
+						},
+					},
+				},
+				"timeoutSeconds": {
+					Description: "How long to wait for the scan to finish before failing the " +
+						"update. Defaults to 600 seconds.",
+					TypeSpec: schema.TypeSpec{
+						Type: "integer",
+					},
+				},
+			},
+		},
+	}
+}
+
+func scanSeverity() schema.ComplexTypeSpec {
+	return schema.ComplexTypeSpec{
+		ObjectTypeSpec: schema.ObjectTypeSpec{
+			Type:        "string",
+			Description: "Severity of a vulnerability found by a scan",
+		},
+		Enum: []schema.EnumValueSpec{
+			{Value: "INFORMATIONAL"},
+			{Value: "LOW"},
+			{Value: "MEDIUM"},
+			{Value: "HIGH"},
+			{Value: "CRITICAL"},
+		},
+	}
+}
+
+func scanFindingsSummary() schema.ComplexTypeSpec {
+	return schema.ComplexTypeSpec{
+		ObjectTypeSpec: schema.ObjectTypeSpec{
+			Type:        "object",
+			Description: "Summary of the findings of an image scan",
+			Properties: map[string]schema.PropertySpec{
+				"severityCounts": {
+					Description: "Number of findings of each severity.",
+					TypeSpec: schema.TypeSpec{
+						Type: "object",
+						AdditionalProperties: &schema.TypeSpec{
+							Type: "integer",
+						},
+					},
+				},
+				"blockingVulnerabilities": {
+					Description: "IDs of the vulnerabilities at or above the severity threshold.",
+					TypeSpec: schema.TypeSpec{
+						Type: "array",
+						Items: &schema.TypeSpec{
+							Type: "string",
+						},
+					},
+				},
+				"allowedVulnerabilities": {
+					Description: "IDs of the vulnerabilities at or above the severity threshold " +
+						"that were allowed.",
+					TypeSpec: schema.TypeSpec{
+						Type: "array",
+						Items: &schema.TypeSpec{
+							Type: "string",
+						},
+					},
+				},
+			},
+			Required: []string{"severityCounts", "blockingVulnerabilities", "allowedVulnerabilities"},
+		},
+	}
+}
+
+func imageSigning() schema.ComplexTypeSpec {
+	return schema.ComplexTypeSpec{
+		ObjectTypeSpec: schema.ObjectTypeSpec{
+			Type: "object",
+			Description: "Signs a pushed image. Exactly one of `signingProfileArn` or " +
+				"`kmsKeyArn` must be specified",
+			Properties: map[string]schema.PropertySpec{
+				"signingProfileArn": {
+					Description: "ARN of an AWS Signer signing profile for the Notation " +
+						"container signing platform. Signs with the `notation` CLI and the AWS " +
+						"Signer plugin.",
+					TypeSpec: schema.TypeSpec{
+						Type: "string",
+					},
+				},
+				"kmsKeyArn": {
+					Description: "ARN of an asymmetric KMS key to sign with. Signs with the " +
+						"`cosign` CLI and stores the signature next to the image. Signatures aren't " +
+						"uploaded to a public transparency log.",
+					TypeSpec: schema.TypeSpec{
+						Type: "string",
+					},
+				},
+			},
+		},
+	}
+}
+
+func imageAttestations() schema.ComplexTypeSpec {
+	return schema.ComplexTypeSpec{
+		ObjectTypeSpec: schema.ObjectTypeSpec{
+			Type:        "object",
+			Description: "Attestations to attach to a pushed image",
+			Properties: map[string]schema.PropertySpec{
+				"sbom": {
+					Description: "Attach an SPDX SBOM of each platform image, generated by the " +
+						"BuildKit scanner.",
+					TypeSpec: schema.TypeSpec{
+						Type: "boolean",
+					},
+				},
+				"provenance": {
+					Description: "Attach SLSA provenance describing how the image was built, " +
+						"recorded by BuildKit.",
+					TypeSpec: schema.TypeSpec{
+						Type: "boolean",
+					},
+				},
+			},
+		},
+	}
+}
+
+func imageCopySource() schema.ComplexTypeSpec {
+	return schema.ComplexTypeSpec{
+		ObjectTypeSpec: schema.ObjectTypeSpec{
+			Type:        "object",
+			Description: "An image in an ECR repository to copy from",
+			Properties: map[string]schema.PropertySpec{
+				"repositoryUrl": {
+					Description: "The URL of the repository to copy from (in the form " +
+						"aws_account_id.dkr.ecr.region.amazonaws.com/repositoryName). It may " +
+						"belong to another account or region, as long as the repository policy " +
+						"allows pulling.",
+					TypeSpec: schema.TypeSpec{
+						Type: "string",
+					},
+				},
+				"digest": {
+					Description: "The digest of the image to copy. Copying by digest makes the " +
+						"promotion reproducible. Exactly one of `digest` or `tag` must be " +
+						"specified.",
+					TypeSpec: schema.TypeSpec{
+						Type: "string",
+					},
+				},
+				"tag": {
+					Description: "The tag of the image to copy. The tag is resolved to a digest " +
+						"on every update. Exactly one of `digest` or `tag` must be specified.",
+					TypeSpec: schema.TypeSpec{
+						Type: "string",
+					},
+				},
+			},
+			Required: []string{"repositoryUrl"},
+		},
+	}
+}
+
+func repositoryPreset() schema.ComplexTypeSpec {
+	return schema.ComplexTypeSpec{
+		ObjectTypeSpec: schema.ObjectTypeSpec{
+			Type:        "string",
+			Description: "A standard set of repository settings",
+		},
+		Enum: []schema.EnumValueSpec{
+			{
+				Name: "SecureDefault",
+				Description: "KMS encryption, immutable tags, scan on push and a lifecycle " +
+					"policy that expires untagged images after 14 days and keeps the 500 " +
+					"most recent images",
+				Value: "secure-default",
+			},
+		},
+	}
+}
+
+func repositoryNamingPolicy() schema.ComplexTypeSpec {
+	return schema.ComplexTypeSpec{
+		ObjectTypeSpec: schema.ObjectTypeSpec{
+			Type:        "object",
+			Description: "Rules repository names must follow",
+			Properties: map[string]schema.PropertySpec{
+				"prefix": {
+					Description: "A prefix the name must start with, e.g. a namespace like " +
+						"`payments/`.",
+					TypeSpec: schema.TypeSpec{
+						Type:  "string",
+						Plain: true,
+					},
+				},
+				"pattern": {
+					Description: "A regular expression the whole name must match, e.g. " +
+						"`^[a-z]+/[a-z0-9-]+$`.",
+					TypeSpec: schema.TypeSpec{
+						Type:  "string",
+						Plain: true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func repositorySettings() schema.ComplexTypeSpec {
+	return schema.ComplexTypeSpec{
+		ObjectTypeSpec: schema.ObjectTypeSpec{
+			Type:        "object",
+			Description: "The settings a repository was created with",
+			Properties: map[string]schema.PropertySpec{
+				"preset": {
+					Description: "The preset the settings are based on, if any.",
+					TypeSpec: schema.TypeSpec{
+						Type: "string",
+					},
+				},
+				"encryptionType": {
+					Description: "The encryption type of the repository, `AES256` or `KMS`.",
+					TypeSpec: schema.TypeSpec{
+						Type: "string",
+					},
+				},
+				"kmsKey": {
+					Description: "The ARN of the KMS key the repository is encrypted with.",
+					TypeSpec: schema.TypeSpec{
+						Type: "string",
+					},
+				},
+				"imageTagMutability": {
+					Description: "Whether tags can be overwritten.",
+					TypeSpec: schema.TypeSpec{
+						Type: "string",
+					},
+				},
+				"scanOnPush": {
+					Description: "Whether images are scanned when they are pushed.",
+					TypeSpec: schema.TypeSpec{
+						Type: "boolean",
+					},
+				},
+				"lifecyclePolicy": {
+					Description: "The lifecycle policy document of the repository as JSON.",
+					TypeSpec: schema.TypeSpec{
+						Type: "string",
+					},
+				},
+			},
+			Required: []string{"encryptionType", "imageTagMutability", "scanOnPush"},
+		},
+	}
+}
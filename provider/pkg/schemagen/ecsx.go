@@ -15,6 +15,7 @@
 package gen
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/pulumi/pulumi/pkg/v3/codegen/schema"
@@ -45,6 +46,14 @@ func generateEcs(awsSpec, awsNativeSpec schema.PackageSpec) schema.PackageSpec {
 					Properties:  ec2TaskDefinitionResource.InputProperties,
 				},
 			},
+			"awsx:ecs:TaskDefinitionSidecar":            taskDefinitionSidecar(),
+			"awsx:ecs:TaskDefinitionSidecarPreset":      taskDefinitionSidecarPreset(),
+			"awsx:ecs:FargateTaskSize":                  fargateTaskSize(),
+			"awsx:ecs:FargateCpuArchitecture":           fargateCpuArchitecture(),
+			"awsx:ecs:TaskDefinitionEfsVolume":          taskDefinitionEfsVolume(),
+			"awsx:ecs:ServiceExec":                      serviceExec(),
+			"awsx:ecs:ServiceFrontend":                  serviceFrontend(awsSpec),
+			"awsx:ecs:ServiceLoadBalancerSecurityGroup": serviceLoadBalancerSecurityGroup(),
 		},
 	}
 
@@ -91,6 +100,8 @@ func ec2Service(awsSpec schema.PackageSpec) schema.ResourceSpec {
 		},
 	}
 
+	inputProperties["exec"] = serviceExecProperty()
+	inputProperties["loadBalancerSecurityGroups"] = serviceLoadBalancerSecurityGroupsProperty()
 	inputProperties["useClusterDefaultCapacityProviderStrategy"] = schema.PropertySpec{
 		Description: "If `true`, this service will use the cluster's default capacity provider " +
 			"strategy. When enabled, this provider omits both `launchType` and " +
@@ -123,6 +134,9 @@ func ec2Service(awsSpec schema.PackageSpec) schema.ResourceSpec {
 						Ref: packageRef(awsSpec, "/resources/aws:ecs%2FtaskDefinition:TaskDefinition"),
 					},
 				},
+				"execLogGroup":                     serviceExecLogGroupOutput(awsSpec),
+				"execBucket":                       serviceExecBucketOutput(awsSpec),
+				"loadBalancerIngressSecurityGroup": serviceLoadBalancerIngressSecurityGroupOutput(awsSpec),
 			},
 			Required: []string{"service"},
 		},
@@ -150,6 +164,8 @@ func fargateService(awsSpec schema.PackageSpec) schema.ResourceSpec {
 		}
 	}
 
+	inputProperties["exec"] = serviceExecProperty()
+	inputProperties["loadBalancerSecurityGroups"] = serviceLoadBalancerSecurityGroupsProperty()
 	inputProperties["useClusterDefaultCapacityProviderStrategy"] = schema.PropertySpec{
 		Description: "If `true`, this service will use the cluster's default capacity provider " +
 			"strategy. When enabled, this provider omits both `launchType` and " +
@@ -196,6 +212,27 @@ func fargateService(awsSpec schema.PackageSpec) schema.ResourceSpec {
 			Type: "boolean",
 		},
 	}
+	inputProperties["vpc"] = schema.PropertySpec{
+		Description: "VPC to run the service in if [networkConfiguration] isn't provided, and " +
+			"to place the load balancers of the [frontends] in. Defaults to the default VPC.",
+		TypeSpec: schema.TypeSpec{
+			Ref:   "#/resources/awsx:ec2:Vpc",
+			Plain: true,
+		},
+	}
+	inputProperties["frontends"] = schema.PropertySpec{
+		Description: "Application load balancers to create in front of the service, keyed by " +
+			"name. Each frontend is placed in the subnets of a type of the [vpc], e.g. a public " +
+			"frontend for the internet and an internal one in private subnets.",
+		TypeSpec: schema.TypeSpec{
+			Type:  "object",
+			Plain: true,
+			AdditionalProperties: &schema.TypeSpec{
+				Ref:   "#/types/awsx:ecs:ServiceFrontend",
+				Plain: true,
+			},
+		},
+	}
 
 	return schema.ResourceSpec{
 		IsComponent: true,
@@ -216,6 +253,25 @@ func fargateService(awsSpec schema.PackageSpec) schema.ResourceSpec {
 						Ref: packageRef(awsSpec, "/resources/aws:ecs%2FtaskDefinition:TaskDefinition"),
 					},
 				},
+				"execLogGroup":                     serviceExecLogGroupOutput(awsSpec),
+				"execBucket":                       serviceExecBucketOutput(awsSpec),
+				"loadBalancerIngressSecurityGroup": serviceLoadBalancerIngressSecurityGroupOutput(awsSpec),
+				"frontends": {
+					Description: "Load balancers of the [frontends], keyed by name",
+					TypeSpec: schema.TypeSpec{
+						Type: "object",
+						AdditionalProperties: &schema.TypeSpec{
+							Ref: "#/resources/awsx:lb:ApplicationLoadBalancer",
+						},
+					},
+				},
+				"frontendDnsNames": {
+					Description: "DNS names of the load balancers of the [frontends], keyed by name",
+					TypeSpec: schema.TypeSpec{
+						Type:                 "object",
+						AdditionalProperties: &schema.TypeSpec{Type: "string"},
+					},
+				},
 			},
 			Required: []string{"service"},
 		},
@@ -238,6 +294,14 @@ func fargateTaskDefinition(awsSpec schema.PackageSpec) schema.ResourceSpec {
 	delete(inputProperties, "networkMode")             // the networkMode of FargateTaskDefinition is "awsvpc"
 	delete(inputProperties, "requiresCompatibilities") // the requiresCompatibilities of FargateTaskDefinition is "FARGATE"
 
+	inputProperties["architecture"] = schema.PropertySpec{
+		Description: "The CPU architecture the task runs on. Defaults to `X86_64`.\n\n" +
+			"Cannot be used in combination with [runtimePlatform].",
+		TypeSpec: schema.TypeSpec{
+			Ref:   "#/types/awsx:ecs:FargateCpuArchitecture",
+			Plain: true,
+		},
+	}
 	inputProperties["container"] = schema.PropertySpec{
 		Description: "Single container to make a TaskDefinition from.  Useful for " +
 			"simple cases where there aren't\nmultiple containers, especially " +
@@ -264,11 +328,13 @@ func fargateTaskDefinition(awsSpec schema.PackageSpec) schema.ResourceSpec {
 	inputProperties["cpu"] = schema.PropertySpec{
 		Description: "The number of cpu units used by the task. If not provided, " +
 			"a default will be computed based on the cumulative needs specified " +
-			"by [containerDefinitions]",
+			"by [containerDefinitions]\n\nIf both [cpu] and [memory] are provided, " +
+			"they must be one of the combinations supported by Fargate.",
 		TypeSpec: schema.TypeSpec{
 			Type: "string",
 		},
 	}
+	inputProperties["efsVolumes"] = taskDefinitionEfsVolumesProperty()
 	inputProperties["executionRole"] = schema.PropertySpec{
 		Description: "The execution role that the Amazon ECS container agent and " +
 			"the Docker daemon can assume.\nWill be created automatically " +
@@ -295,11 +361,21 @@ func fargateTaskDefinition(awsSpec schema.PackageSpec) schema.ResourceSpec {
 	inputProperties["memory"] = schema.PropertySpec{
 		Description: "The amount (in MiB) of memory used by the task.  If not provided, " +
 			"a default will be computed\nbased on the cumulative needs " +
-			"specified by [containerDefinitions]",
+			"specified by [containerDefinitions]\n\nIf both [cpu] and [memory] are provided, " +
+			"they must be one of the combinations supported by Fargate.",
 		TypeSpec: schema.TypeSpec{
 			Type: "string",
 		},
 	}
+	inputProperties["size"] = schema.PropertySpec{
+		Description: "A supported combination of cpu and memory for the task.\n\n" +
+			"Cannot be used in combination with [cpu] or [memory].",
+		TypeSpec: schema.TypeSpec{
+			Ref:   "#/types/awsx:ecs:FargateTaskSize",
+			Plain: true,
+		},
+	}
+	inputProperties["sidecars"] = taskDefinitionSidecarsProperty()
 	inputProperties["taskRole"] = schema.PropertySpec{
 		Description: "IAM role that allows your Amazon ECS container task to make " +
 			"calls to other AWS services.\nWill be created automatically " +
@@ -405,6 +481,7 @@ func ec2TaskDefinition(awsSpec schema.PackageSpec) schema.ResourceSpec {
 			Type: "string",
 		},
 	}
+	inputProperties["efsVolumes"] = taskDefinitionEfsVolumesProperty()
 	inputProperties["executionRole"] = schema.PropertySpec{
 		Description: "The execution role that the Amazon ECS container agent and " +
 			"the Docker daemon can assume.\nWill be created automatically " +
@@ -436,6 +513,7 @@ func ec2TaskDefinition(awsSpec schema.PackageSpec) schema.ResourceSpec {
 			Type: "string",
 		},
 	}
+	inputProperties["sidecars"] = taskDefinitionSidecarsProperty()
 	inputProperties["taskRole"] = schema.PropertySpec{
 		Description: "IAM role that allows your Amazon ECS container task to make " +
 			"calls to other AWS services.\nWill be created automatically " +
@@ -496,6 +574,466 @@ func ec2TaskDefinition(awsSpec schema.PackageSpec) schema.ResourceSpec {
 	}
 }
 
+func taskDefinitionEfsVolumesProperty() schema.PropertySpec {
+	return schema.PropertySpec{
+		Description: "EFS file systems to add as volumes to the task, keyed by the volume name " +
+			"to use as `sourceVolume` of the containers' mount points.\nThe task role created " +
+			"by the component is granted access to the file systems.",
+		TypeSpec: schema.TypeSpec{
+			Type: "object",
+			AdditionalProperties: &schema.TypeSpec{
+				Ref:   "#/types/awsx:ecs:TaskDefinitionEfsVolume",
+				Plain: true,
+			},
+			Plain: true,
+		},
+	}
+}
+
+func taskDefinitionEfsVolume() schema.ComplexTypeSpec {
+	return schema.ComplexTypeSpec{
+		ObjectTypeSpec: schema.ObjectTypeSpec{
+			Type:        "object",
+			Description: "A volume backed by an EFS file system.",
+			Properties: map[string]schema.PropertySpec{
+				"fileSystem": {
+					Description: "The file system to mount.",
+					TypeSpec: schema.TypeSpec{
+						Ref:   "#/resources/awsx:efs:FileSystem",
+						Plain: true,
+					},
+				},
+				"rootDirectory": {
+					Description: "Directory of the file system to mount as the root of the volume. " +
+						"Defaults to the root of the file system. Must be omitted when using an " +
+						"access point.",
+					TypeSpec: schema.TypeSpec{
+						Type: "string",
+					},
+				},
+				"accessPointId": {
+					Description: "ID of the EFS access point to mount the file system through.",
+					TypeSpec: schema.TypeSpec{
+						Type: "string",
+					},
+				},
+				"containerPath": {
+					Description: "Path to mount the volume at in the containers. When set, a " +
+						"mount point is added to the containers listed in [containerNames]. " +
+						"Otherwise add the mount points to the containers yourself.",
+					TypeSpec: schema.TypeSpec{
+						Type: "string",
+					},
+				},
+				"containerNames": {
+					Description: "Names of the containers to mount the volume into at " +
+						"[containerPath]. Defaults to all containers of the task.",
+					TypeSpec: schema.TypeSpec{
+						Type:  "array",
+						Plain: true,
+						Items: &schema.TypeSpec{
+							Type:  "string",
+							Plain: true,
+						},
+					},
+				},
+				"readOnly": {
+					Description: "Only grant the task read access to the file system and " +
+						"mount the volume read-only into the containers. Defaults to `false`.",
+					TypeSpec: schema.TypeSpec{
+						Type:  "boolean",
+						Plain: true,
+					},
+				},
+				"iamAuthorization": {
+					Description: "Authorize access to the file system with the task role. " +
+						"Defaults to `true`.",
+					TypeSpec: schema.TypeSpec{
+						Type:  "boolean",
+						Plain: true,
+					},
+				},
+			},
+			Required: []string{"fileSystem"},
+		},
+	}
+}
+
+func serviceExecProperty() schema.PropertySpec {
+	return schema.PropertySpec{
+		Description: "Enables ECS Exec for the service. Sets `enableExecuteCommand` and grants the " +
+			"auto-created task role the SSM messages permissions ECS Exec needs.",
+		TypeSpec: schema.TypeSpec{
+			Ref:   "#/types/awsx:ecs:ServiceExec",
+			Plain: true,
+		},
+	}
+}
+
+func serviceLoadBalancerSecurityGroupsProperty() schema.PropertySpec {
+	return schema.PropertySpec{
+		Description: "Security groups of the load balancers forwarding to the service. A security " +
+			"group allowing traffic from them on the container ports of the [loadBalancers] is " +
+			"created and added to the [networkConfiguration] of the service. With a given " +
+			"[networkConfiguration], the security groups of the load balancers the target groups " +
+			"of the service are attached to are allowed as well, without egress rules. List them " +
+			"here to add egress rules to them.",
+		TypeSpec: schema.TypeSpec{
+			Type:  "array",
+			Plain: true,
+			Items: &schema.TypeSpec{
+				Ref:   "#/types/awsx:ecs:ServiceLoadBalancerSecurityGroup",
+				Plain: true,
+			},
+		},
+	}
+}
+
+func serviceLoadBalancerSecurityGroup() schema.ComplexTypeSpec {
+	return schema.ComplexTypeSpec{
+		ObjectTypeSpec: schema.ObjectTypeSpec{
+			Type:        "object",
+			Description: "A security group of a load balancer forwarding to a service.",
+			Properties: map[string]schema.PropertySpec{
+				"securityGroupId": {
+					Description: "ID of the security group.",
+					TypeSpec: schema.TypeSpec{
+						Type: "string",
+					},
+				},
+				"egress": {
+					Description: "Whether to add egress rules to the security group allowing " +
+						"traffic to the service on each of its container ports. Defaults to `true`. Disable " +
+						"it for security groups defining their rules inline, which conflicts with " +
+						"separate rules. The [defaultSecurityGroup] created by an " +
+						"`ApplicationLoadBalancer` is such a group and already allows egress on all " +
+						"TCP ports.",
+					TypeSpec: schema.TypeSpec{
+						Type:  "boolean",
+						Plain: true,
+					},
+				},
+			},
+			Required: []string{"securityGroupId"},
+		},
+	}
+}
+
+func serviceLoadBalancerIngressSecurityGroupOutput(awsSpec schema.PackageSpec) schema.PropertySpec {
+	return schema.PropertySpec{
+		Description: "Security group allowing traffic from the [loadBalancerSecurityGroups] and the " +
+			"load balancers of the target groups to the service, if created.",
+		TypeSpec: awsResource(awsSpec, "aws:ec2/securityGroup:SecurityGroup"),
+	}
+}
+
+func serviceFrontend(awsSpec schema.PackageSpec) schema.ComplexTypeSpec {
+	return schema.ComplexTypeSpec{
+		ObjectTypeSpec: schema.ObjectTypeSpec{
+			Type: "object",
+			Description: "An application load balancer forwarding to a container port of the " +
+				"service. Load balancers in private or isolated subnets are internal.",
+			Properties: map[string]schema.PropertySpec{
+				"subnetType": {
+					Description: "Type of the subnets of the [vpc] to place the load balancer in. " +
+						"Defaults to `Public`.",
+					TypeSpec: schema.TypeSpec{
+						Ref:   localRef("ec2", "SubnetType"),
+						Plain: true,
+					},
+				},
+				"containerName": {
+					Description: "Name of the container to forward requests to. Defaults to the " +
+						"container of the task definition if it has a single container.",
+					TypeSpec: schema.TypeSpec{
+						Type: "string",
+					},
+				},
+				"containerPort": {
+					Description: "Port of the container to forward requests to.",
+					TypeSpec: schema.TypeSpec{
+						Type: "integer",
+					},
+				},
+				"port": {
+					Description: "Port of the HTTP listener of the load balancer. Defaults to 80.",
+					TypeSpec: schema.TypeSpec{
+						Type: "integer",
+					},
+				},
+				"healthCheck": {
+					Description: "Health check of the target group of the load balancer.",
+					TypeSpec: schema.TypeSpec{
+						Ref: packageRef(awsSpec, "/types/aws:lb%2FTargetGroupHealthCheck:TargetGroupHealthCheck"),
+					},
+				},
+			},
+			Required: []string{"containerPort"},
+		},
+	}
+}
+
+func serviceExec() schema.ComplexTypeSpec {
+	return schema.ComplexTypeSpec{
+		ObjectTypeSpec: schema.ObjectTypeSpec{
+			Type: "object",
+			Description: "ECS Exec configuration of a service. Session logs are written by the tasks, " +
+				"so the auto-created task role is granted access to the log group and bucket. " +
+				"ECS only writes session logs when the cluster's `executeCommandConfiguration` " +
+				"points at them, and the service doesn't manage the cluster. When the [cluster] " +
+				"is passed as a resource, the deployment fails unless its configuration logs to " +
+				"existing log groups and buckets given here and [kmsKeyId] is set for clusters " +
+				"encrypting sessions. Destinations created by the service and clusters passed by " +
+				"ARN can't be checked, so a warning is logged instead. Use existing log " +
+				"destinations to configure both the cluster and the service with them.",
+			Properties: map[string]schema.PropertySpec{
+				"logGroup": {
+					Description: "Log group to write ECS Exec session logs to.",
+					TypeSpec: schema.TypeSpec{
+						Ref:   "#/types/awsx:awsx:OptionalLogGroup",
+						Plain: true,
+					},
+				},
+				"s3Bucket": {
+					Description: "S3 bucket to write ECS Exec session logs to. " +
+						"Session logs are only written to S3 if specified.",
+					TypeSpec: schema.TypeSpec{
+						Ref:   "#/types/awsx:awsx:DefaultBucket",
+						Plain: true,
+					},
+				},
+				"s3KeyPrefix": {
+					Description: "Prefix of the S3 keys session logs are written to.",
+					TypeSpec: schema.TypeSpec{
+						Type: "string",
+					},
+				},
+				"kmsKeyId": {
+					Description: "ID or ARN of the KMS key the cluster encrypts ECS Exec sessions " +
+						"with. The auto-created task role is granted `kms:Decrypt` on the key.",
+					TypeSpec: schema.TypeSpec{
+						Type: "string",
+					},
+				},
+			},
+		},
+	}
+}
+
+func serviceExecLogGroupOutput(awsSpec schema.PackageSpec) schema.PropertySpec {
+	return schema.PropertySpec{
+		Description: "Log group for ECS Exec session logs, if created.",
+		TypeSpec:    awsResource(awsSpec, "aws:cloudwatch/logGroup:LogGroup"),
+	}
+}
+
+func serviceExecBucketOutput(awsSpec schema.PackageSpec) schema.PropertySpec {
+	return schema.PropertySpec{
+		Description: "S3 bucket for ECS Exec session logs, if created.",
+		TypeSpec:    awsResource(awsSpec, "aws:s3/bucket:Bucket"),
+	}
+}
+
+func taskDefinitionSidecarsProperty() schema.PropertySpec {
+	return schema.PropertySpec{
+		Description: "Sidecar containers to inject into the task definition from a set of presets. " +
+			"Each preset adds its container, applies the matching changes to the main containers " +
+			"(log configuration, environment and `dependsOn` ordering) and grants the required " +
+			"permissions to the auto-created task role. In `bridge` network mode the main " +
+			"containers are linked to agent sidecars and reach them by container name.",
+		TypeSpec: schema.TypeSpec{
+			Type: "array",
+			Items: &schema.TypeSpec{
+				Ref:   "#/types/awsx:ecs:TaskDefinitionSidecar",
+				Plain: true,
+			},
+			Plain: true,
+		},
+	}
+}
+
+func taskDefinitionSidecar() schema.ComplexTypeSpec {
+	return schema.ComplexTypeSpec{
+		ObjectTypeSpec: schema.ObjectTypeSpec{
+			Type:        "object",
+			Description: "A sidecar container created from a preset.",
+			Properties: map[string]schema.PropertySpec{
+				"preset": {
+					Description: "The preset to create the sidecar container from.",
+					TypeSpec: schema.TypeSpec{
+						Ref:   "#/types/awsx:ecs:TaskDefinitionSidecarPreset",
+						Plain: true,
+					},
+				},
+				"name": {
+					Description: "Name of the sidecar container. Defaults to `log_router` for `FireLens`, " +
+						"`xray-daemon` for `XRay`, `datadog-agent` for `Datadog` and " +
+						"`cloudwatch-agent` for `CloudWatchAgent`.",
+					TypeSpec: schema.TypeSpec{
+						Type:  "string",
+						Plain: true,
+					},
+				},
+				"image": {
+					Description: "Image to use for the sidecar container instead of the preset's default " +
+						"image. The default images are pinned to a version.",
+					TypeSpec: schema.TypeSpec{
+						Type: "string",
+					},
+				},
+				"cpu": {
+					Description: "The number of cpu units reserved for the sidecar container.",
+					TypeSpec: schema.TypeSpec{
+						Type: "integer",
+					},
+				},
+				"memory": {
+					Description: "The amount (in MiB) of memory reserved for the sidecar container.",
+					TypeSpec: schema.TypeSpec{
+						Type: "integer",
+					},
+				},
+				"containerNames": {
+					Description: "Names of the main containers the preset is applied to. " +
+						"Defaults to all containers of the task definition.",
+					TypeSpec: schema.TypeSpec{
+						Type: "array",
+						Items: &schema.TypeSpec{
+							Type:  "string",
+							Plain: true,
+						},
+						Plain: true,
+					},
+				},
+				"firelensOptions": {
+					Description: "Options of the `awsfirelens` log configuration set on the main " +
+						"containers. Only used by the `FireLens` preset. Defaults to sending the " +
+						"logs to the task definition's log group with the `cloudwatch_logs` output.",
+					TypeSpec: schema.TypeSpec{
+						Type: "object",
+						AdditionalProperties: &schema.TypeSpec{
+							Type: "string",
+						},
+					},
+				},
+				"datadogApiKeySecretArn": {
+					Description: "ARN of the Secrets Manager secret holding the Datadog API key. " +
+						"Required by the `Datadog` preset.",
+					TypeSpec: schema.TypeSpec{
+						Type: "string",
+					},
+				},
+				"datadogSite": {
+					Description: "The Datadog site to send data to, e.g. `datadoghq.eu`. " +
+						"Only used by the `Datadog` preset. Defaults to `datadoghq.com`.",
+					TypeSpec: schema.TypeSpec{
+						Type: "string",
+					},
+				},
+				"cloudwatchAgentConfig": {
+					Description: "JSON configuration of the CloudWatch agent. Only used by the " +
+						"`CloudWatchAgent` preset. Defaults to collecting StatsD metrics and " +
+						"embedded metric format logs.",
+					TypeSpec: schema.TypeSpec{
+						Type: "string",
+					},
+				},
+			},
+			Required: []string{"preset"},
+		},
+	}
+}
+
+func taskDefinitionSidecarPreset() schema.ComplexTypeSpec {
+	return schema.ComplexTypeSpec{
+		ObjectTypeSpec: schema.ObjectTypeSpec{
+			Type:        "string",
+			Description: "Preset for a sidecar container.",
+		},
+		Enum: []schema.EnumValueSpec{
+			{
+				Value: "FireLens",
+				Description: "AWS for Fluent Bit log router. Routes the logs of the main " +
+					"containers through FireLens.",
+			},
+			{
+				Value: "XRay",
+				Description: "AWS X-Ray daemon. Exposes the daemon address to the main " +
+					"containers through `AWS_XRAY_DAEMON_ADDRESS`.",
+			},
+			{
+				Value: "Datadog",
+				Description: "Datadog agent. Exposes the agent to the main containers " +
+					"through `DD_AGENT_HOST`.",
+			},
+			{
+				Value: "CloudWatchAgent",
+				Description: "Amazon CloudWatch agent collecting StatsD metrics and " +
+					"embedded metric format logs from the main containers.",
+			},
+		},
+	}
+}
+
+// fargateTaskSizes lists the supported task sizes of Fargate, see
+// https://docs.aws.amazon.com/AmazonECS/latest/developerguide/fargate-tasks-services.html#fargate-tasks-size
+// awsx/ecs/fargateMemoryAndCpu.test.ts checks that the generated enum matches the sizes validated by
+// the provider.
+var fargateTaskSizes = []struct {
+	vcpu          float64
+	minMemoryMiB  int
+	maxMemoryMiB  int
+	memoryStepMiB int
+}{
+	{vcpu: 0.25, minMemoryMiB: 512, maxMemoryMiB: 512, memoryStepMiB: 512},
+	{vcpu: 0.25, minMemoryMiB: 1024, maxMemoryMiB: 2048, memoryStepMiB: 1024},
+	{vcpu: 0.5, minMemoryMiB: 1024, maxMemoryMiB: 4096, memoryStepMiB: 1024},
+	{vcpu: 1, minMemoryMiB: 2048, maxMemoryMiB: 8192, memoryStepMiB: 1024},
+	{vcpu: 2, minMemoryMiB: 4096, maxMemoryMiB: 16384, memoryStepMiB: 1024},
+	{vcpu: 4, minMemoryMiB: 8192, maxMemoryMiB: 30720, memoryStepMiB: 1024},
+	{vcpu: 8, minMemoryMiB: 16384, maxMemoryMiB: 61440, memoryStepMiB: 4096},
+	{vcpu: 16, minMemoryMiB: 32768, maxMemoryMiB: 122880, memoryStepMiB: 8192},
+}
+
+func fargateTaskSize() schema.ComplexTypeSpec {
+	var values []schema.EnumValueSpec
+	for _, size := range fargateTaskSizes {
+		for memory := size.minMemoryMiB; memory <= size.maxMemoryMiB; memory += size.memoryStepMiB {
+			vcpu := strings.ReplaceAll(fmt.Sprintf("%g", size.vcpu), ".", "_")
+			mem := fmt.Sprintf("%dMiB", memory)
+			if memory >= 1024 {
+				mem = fmt.Sprintf("%dGiB", memory/1024)
+			}
+			values = append(values, schema.EnumValueSpec{
+				Name:  fmt.Sprintf("X86_%svCPU_%s", vcpu, mem),
+				Value: fmt.Sprintf("%gvCPU-%s", size.vcpu, mem),
+				Description: fmt.Sprintf("%g vCPU (%d cpu units) and %d MiB of memory.",
+					size.vcpu, int(size.vcpu*1024), memory),
+			})
+		}
+	}
+	return schema.ComplexTypeSpec{
+		ObjectTypeSpec: schema.ObjectTypeSpec{
+			Type:        "string",
+			Description: "A combination of cpu and memory supported by Fargate.",
+		},
+		Enum: values,
+	}
+}
+
+func fargateCpuArchitecture() schema.ComplexTypeSpec {
+	return schema.ComplexTypeSpec{
+		ObjectTypeSpec: schema.ObjectTypeSpec{
+			Type:        "string",
+			Description: "The CPU architecture of a Fargate task.",
+		},
+		Enum: []schema.EnumValueSpec{
+			{Value: "X86_64", Description: "64-bit x86 (Intel or AMD) processors."},
+			{Value: "ARM64", Description: "64-bit ARM (AWS Graviton) processors."},
+		},
+	}
+}
+
 // Do a deep copy of the ContainerDefinition types from AWS-native to avoid re-defining by hand
 // Manually list all dependencies to also copy. If new dependencies are added the SDK builds will fail
 // indicating that we need to add them to be copied here. We're not just referencing from aws-native
@@ -531,9 +1069,78 @@ func containerDefinitionTypes(awsSpec, awsNativeSpec schema.PackageSpec) map[str
 			awsNativeSpec.Types["aws-native:ecs:"+name], "aws-native:ecs:", "awsx:ecs:")
 	}
 	types["awsx:ecs:TaskDefinitionPortMapping"].Properties["targetGroup"] = schema.PropertySpec{
+		Description: "Target group to register the container port with, e.g. the " +
+			"`defaultTargetGroup` of a load balancer or one of its named `targetGroups`.",
 		TypeSpec: schema.TypeSpec{
 			Ref: packageRef(awsSpec, "/resources/aws:lb%2FtargetGroup:TargetGroup"),
 		},
 	}
+	types["awsx:ecs:TaskDefinitionContainerDefinition"].Properties["secretSources"] = schema.PropertySpec{
+		Description: "Secrets to expose to the container as environment variables, keyed by the " +
+			"name of the environment variable.\nThe execution role created by the component is " +
+			"granted read access to the referenced secrets and parameters.",
+		TypeSpec: schema.TypeSpec{
+			Type: "object",
+			AdditionalProperties: &schema.TypeSpec{
+				Ref:   "#/types/awsx:ecs:TaskDefinitionSecretSource",
+				Plain: true,
+			},
+			Plain: true,
+		},
+	}
+	types["awsx:ecs:TaskDefinitionSecretSource"] = taskDefinitionSecretSource()
 	return types
 }
+
+func taskDefinitionSecretSource() schema.ComplexTypeSpec {
+	return schema.ComplexTypeSpec{
+		ObjectTypeSpec: schema.ObjectTypeSpec{
+			Type: "object",
+			Description: "A Secrets Manager secret or SSM Parameter Store parameter to expose " +
+				"to a container.",
+			Properties: map[string]schema.PropertySpec{
+				"secretArn": {
+					Description: "ARN of the Secrets Manager secret. " +
+						"Only one of [secretArn] or [parameterName] can be specified.",
+					TypeSpec: schema.TypeSpec{
+						Type: "string",
+					},
+				},
+				"jsonKey": {
+					Description: "Key of the value to use if the secret is a JSON document. " +
+						"Defaults to the whole secret.",
+					TypeSpec: schema.TypeSpec{
+						Type: "string",
+					},
+				},
+				"versionStage": {
+					Description: "Staging label of the secret version to use, e.g. `AWSPREVIOUS`. " +
+						"Defaults to `AWSCURRENT`.",
+					TypeSpec: schema.TypeSpec{
+						Type: "string",
+					},
+				},
+				"versionId": {
+					Description: "Unique identifier of the secret version to use.",
+					TypeSpec: schema.TypeSpec{
+						Type: "string",
+					},
+				},
+				"parameterName": {
+					Description: "Name or ARN of the SSM Parameter Store parameter. " +
+						"Only one of [secretArn] or [parameterName] can be specified.",
+					TypeSpec: schema.TypeSpec{
+						Type: "string",
+					},
+				},
+				"kmsKeyArn": {
+					Description: "ARN of the customer managed KMS key the secret or parameter " +
+						"is encrypted with. Not required for the AWS managed keys.",
+					TypeSpec: schema.TypeSpec{
+						Type: "string",
+					},
+				},
+			},
+		},
+	}
+}
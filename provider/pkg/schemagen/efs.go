@@ -0,0 +1,133 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gen
+
+import (
+	"github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+)
+
+func generateEfs(awsSpec schema.PackageSpec) schema.PackageSpec {
+	return schema.PackageSpec{
+		Resources: map[string]schema.ResourceSpec{
+			"awsx:efs:FileSystem": fileSystem(awsSpec),
+		},
+	}
+}
+
+func fileSystem(awsSpec schema.PackageSpec) schema.ResourceSpec {
+	inputProperties := map[string]schema.PropertySpec{
+		"subnetIds": {
+			Description: "The subnets to create the mount targets in, e.g. the private subnets " +
+				"of a VPC. A mount target is created in each subnet, so the subnets must be " +
+				"in distinct availability zones.",
+			TypeSpec: schema.TypeSpec{
+				Type:  "array",
+				Plain: true,
+				Items: &schema.TypeSpec{
+					Type: "string",
+				},
+			},
+		},
+		"encrypted": {
+			Description: "Whether the file system is encrypted at rest. Defaults to `true`.",
+			TypeSpec: schema.TypeSpec{
+				Type: "boolean",
+			},
+		},
+		"kmsKeyId": {
+			Description: "ARN of the KMS key used to encrypt the file system. Defaults to " +
+				"the AWS managed key.",
+			TypeSpec: schema.TypeSpec{
+				Type: "string",
+			},
+		},
+		"performanceMode": {
+			Description: "The performance mode of the file system, either `generalPurpose` " +
+				"or `maxIO`. Defaults to `generalPurpose`.",
+			TypeSpec: schema.TypeSpec{
+				Type: "string",
+			},
+		},
+		"throughputMode": {
+			Description: "The throughput mode of the file system, one of `bursting`, " +
+				"`elastic` or `provisioned`.",
+			TypeSpec: schema.TypeSpec{
+				Type: "string",
+			},
+		},
+		"provisionedThroughputInMibps": {
+			Description: "The throughput to provision in MiB/s. Only applicable with " +
+				"the `provisioned` throughput mode.",
+			TypeSpec: schema.TypeSpec{
+				Type: "number",
+			},
+		},
+		"defaultSecurityGroup": {
+			Description: "The security group of the mount targets. By default a security group " +
+				"is created. Unless skipped, the security group allows NFS traffic from the " +
+				"[clientSecurityGroup], which also holds for a given `securityGroupId`.",
+			TypeSpec: schema.TypeSpec{
+				Ref:   "#/types/awsx:awsx:DefaultSecurityGroup",
+				Plain: true,
+			},
+		},
+		"region": {
+			Description: "Region where the file system and its mount targets are managed. " +
+				"Defaults to the region set in the provider configuration.",
+			TypeSpec: schema.TypeSpec{
+				Type: "string",
+			},
+		},
+		"tags": {
+			Description: "Key-value map of resource tags.",
+			TypeSpec: schema.TypeSpec{
+				Type:                 "object",
+				AdditionalProperties: &schema.TypeSpec{Type: "string"},
+			},
+		},
+	}
+
+	return schema.ResourceSpec{
+		IsComponent: true,
+		ObjectTypeSpec: schema.ObjectTypeSpec{
+			Description: "An EFS file system with a mount target in each of the given subnets, " +
+				"which must be in distinct availability zones.\n" +
+				"Pass it to the `efsVolumes` of an ECS task definition to mount it into containers.",
+			Properties: map[string]schema.PropertySpec{
+				"fileSystem": {
+					Description: "Underlying EFS file system resource.",
+					TypeSpec:    awsResource(awsSpec, "aws:efs/fileSystem:FileSystem"),
+				},
+				"mountTargets": {
+					Description: "The mount targets of the file system.",
+					TypeSpec:    arrayOfAwsResource(awsSpec, "aws:efs/mountTarget:MountTarget"),
+				},
+				"securityGroup": {
+					Description: "Default security group of the mount targets, if created.",
+					TypeSpec:    awsResource(awsSpec, "aws:ec2/securityGroup:SecurityGroup"),
+				},
+				"clientSecurityGroup": {
+					Description: "Security group which is allowed to access the file system over " +
+						"NFS, unless the default security group is skipped. ECS services get this " +
+						"security group added when mounting the file system.",
+					TypeSpec: awsResource(awsSpec, "aws:ec2/securityGroup:SecurityGroup"),
+				},
+			},
+			Required: []string{"fileSystem", "mountTargets"},
+		},
+		InputProperties: inputProperties,
+		RequiredInputs:  []string{"subnetIds"},
+	}
+}
@@ -24,11 +24,20 @@ func generateLb(awsSpec schema.PackageSpec) schema.PackageSpec {
 			"awsx:lb:ApplicationLoadBalancer": loadBalancer(awsSpec, false),
 			"awsx:lb:NetworkLoadBalancer":     loadBalancer(awsSpec, true),
 			"awsx:lb:TargetGroupAttachment":   targetGroupAttachment(awsSpec),
+			"awsx:lb:GatewayLoadBalancer":     gatewayLoadBalancer(awsSpec),
+			"awsx:lb:LambdaTarget":            lambdaTarget(awsSpec),
 		},
 		Types: map[string]schema.ComplexTypeSpec{
-			"awsx:lb:Listener":    lbListener(awsSpec),
-			"awsx:lb:TargetGroup": lbTargetGroup(awsSpec),
-			// "awsx:lb:TargetGroupTargetHealthState": lbTargetGroupTargetHealthState(awsSpec),
+			"awsx:lb:Listener":                     lbListener(awsSpec),
+			"awsx:lb:TargetGroup":                  lbTargetGroup(awsSpec),
+			"awsx:lb:ApplicationLoadBalancerHttps": applicationLoadBalancerHttps(),
+			"awsx:lb:ListenerRule":                 lbListenerRule(),
+			"awsx:lb:ListenerRuleAction":           lbListenerRuleAction(awsSpec),
+			"awsx:lb:WeightedForward":              lbWeightedForward(),
+			"awsx:lb:GatewayLoadBalancerEndpoint":  gatewayLoadBalancerEndpoint(),
+			"awsx:lb:AccessLogs":                   lbAccessLogs(),
+			"awsx:lb:WebAclPreset":                 lbWebAclPreset(),
+			"awsx:lb:ListenerTls":                  lbListenerTls(),
 		},
 	}
 }
@@ -71,6 +80,44 @@ func loadBalancer(awsSpec schema.PackageSpec, isNetworkLoadBalancer bool) schema
 			},
 		}
 	}
+	if !isNetworkLoadBalancer {
+		inputProperties["accessLogsBucket"] = schema.PropertySpec{
+			Description: "Write access logs of the load balancer to an S3 bucket. By default a " +
+				"bucket is created with the policy allowing the load balancer to deliver logs " +
+				"in the current region. Only one of [accessLogs] or [accessLogsBucket] can be " +
+				"specified.",
+			TypeSpec: schema.TypeSpec{
+				Ref:   "#/types/awsx:lb:AccessLogs",
+				Plain: true,
+			},
+		}
+		inputProperties["webAclArn"] = schema.PropertySpec{
+			Description: "ARN of a regional WAF web ACL to associate with the load balancer. " +
+				"Only one of [webAclArn] or [webAclPreset] can be specified.",
+			TypeSpec: schema.TypeSpec{
+				Type: "string",
+			},
+		}
+		inputProperties["webAclPreset"] = schema.PropertySpec{
+			Description: "Create a WAF web ACL from a preset of AWS managed rule groups and " +
+				"associate it with the load balancer. Only one of [webAclArn] or [webAclPreset] " +
+				"can be specified.",
+			TypeSpec: schema.TypeSpec{
+				Ref:   "#/types/awsx:lb:WebAclPreset",
+				Plain: true,
+			},
+		}
+		inputProperties["https"] = schema.PropertySpec{
+			Description: "Serve HTTPS for a domain name. Issues a DNS-validated ACM certificate, " +
+				"creates an HTTPS listener on port 443, redirects HTTP on port 80 to it and " +
+				"creates an alias record for the domain. Cannot be used in combination with " +
+				"[listener] or [listeners].",
+			TypeSpec: schema.TypeSpec{
+				Ref:   "#/types/awsx:lb:ApplicationLoadBalancerHttps",
+				Plain: true,
+			},
+		}
+	}
 	inputProperties["defaultTargetGroup"] = schema.PropertySpec{
 		Description: "Options creating a default target group.",
 		TypeSpec: schema.TypeSpec{
@@ -78,6 +125,33 @@ func loadBalancer(awsSpec schema.PackageSpec, isNetworkLoadBalancer bool) schema
 			Plain: true,
 		},
 	}
+	inputProperties["targetGroups"] = schema.PropertySpec{
+		Description: "Additional target groups to create, keyed by name. Listeners and rules can " +
+			"forward to them by name. The name `default` refers to the default target group.",
+		TypeSpec: schema.TypeSpec{
+			Type:  "object",
+			Plain: true,
+			AdditionalProperties: &schema.TypeSpec{
+				Ref:   "#/types/awsx:lb:TargetGroup",
+				Plain: true,
+			},
+		},
+	}
+	if !isNetworkLoadBalancer {
+		inputProperties["weightedForwards"] = schema.PropertySpec{
+			Description: "Forwards splitting requests across several target groups by weight, " +
+				"e.g. for canary deployments, keyed by name. Listeners and rules can forward to " +
+				"them by name like to a target group.",
+			TypeSpec: schema.TypeSpec{
+				Type:  "object",
+				Plain: true,
+				AdditionalProperties: &schema.TypeSpec{
+					Ref:   "#/types/awsx:lb:WeightedForward",
+					Plain: true,
+				},
+			},
+		}
+	}
 	inputProperties["defaultTargetGroupPort"] = schema.PropertySpec{
 		Description: "Port to use to connect with the target. Valid values are ports 1-65535. Defaults to 80.\n",
 		TypeSpec: schema.TypeSpec{
@@ -128,6 +202,15 @@ func loadBalancer(awsSpec schema.PackageSpec, isNetworkLoadBalancer bool) schema
 				Ref: packageRef(awsSpec, "/resources/aws:lb%2ftargetGroup:TargetGroup"),
 			},
 		},
+		"targetGroups": {
+			Description: "Target groups created from [targetGroups], keyed by name",
+			TypeSpec: schema.TypeSpec{
+				Type: "object",
+				AdditionalProperties: &schema.TypeSpec{
+					Ref: packageRef(awsSpec, "/resources/aws:lb%2ftargetGroup:TargetGroup"),
+				},
+			},
+		},
 		"listeners": {
 			Description: "Listeners created as part of this load balancer",
 			TypeSpec: schema.TypeSpec{
@@ -142,6 +225,45 @@ func loadBalancer(awsSpec schema.PackageSpec, isNetworkLoadBalancer bool) schema
 	if isNetworkLoadBalancer {
 		// NLBs don't have a default Security Group
 		delete(outputs, "defaultSecurityGroup")
+		outputs["certificate"] = schema.PropertySpec{
+			Description: "ACM certificate of the TLS listener, if issued for a [tls] [domainName]",
+			TypeSpec: schema.TypeSpec{
+				Ref: packageRef(awsSpec, "/resources/aws:acm%2fcertificate:Certificate"),
+			},
+		}
+	} else {
+		outputs["certificate"] = schema.PropertySpec{
+			Description: "ACM certificate of the HTTPS listener, if [https] is specified",
+			TypeSpec: schema.TypeSpec{
+				Ref: packageRef(awsSpec, "/resources/aws:acm%2fcertificate:Certificate"),
+			},
+		}
+		outputs["accessLogsBucket"] = schema.PropertySpec{
+			Description: "Bucket for the access logs, if created",
+			TypeSpec:    awsResource(awsSpec, "aws:s3/bucket:Bucket"),
+		}
+		outputs["webAcl"] = schema.PropertySpec{
+			Description: "WAF web ACL created from [webAclPreset], if specified",
+			TypeSpec:    awsResource(awsSpec, "aws:wafv2/webAcl:WebAcl"),
+		}
+		outputs["listenerRules"] = schema.PropertySpec{
+			Description: "Rules created for each of the [listeners], in the same order",
+			TypeSpec: schema.TypeSpec{
+				Type: "array",
+				Items: &schema.TypeSpec{
+					Type: "array",
+					Items: &schema.TypeSpec{
+						Ref: packageRef(awsSpec, "/resources/aws:lb%2flistenerRule:ListenerRule"),
+					},
+				},
+			},
+		}
+		outputs["dnsName"] = schema.PropertySpec{
+			Description: "DNS name of the load balancer. The domain name if [https] is specified",
+			TypeSpec: schema.TypeSpec{
+				Type: "string",
+			},
+		}
 	}
 
 	var description string
@@ -182,24 +304,19 @@ func targetGroupAttachment(awsSpec schema.PackageSpec) schema.ResourceSpec {
 				},
 			},
 			"instance": {
-				Description: "EC2 Instance to attach to the Target Group. Exactly 1 of " +
-					"[instance], [instanceId], [lambda] or [lambdaArn] must be provided.",
+				Description: "EC2 Instance to attach to the Target Group.",
 				TypeSpec: schema.TypeSpec{
 					Ref: packageRef(awsSpec, "/resources/aws:ec2%2finstance:Instance"),
 				},
 			},
 			"instanceId": {
-				Description: "ID of an EC2 Instance to attach to the Target Group. Exactly " +
-					"1 of [instance], [instanceId], [lambda] or [lambdaArn] must " +
-					"be provided.",
+				Description: "ID of an EC2 Instance to attach to the Target Group.",
 				TypeSpec: schema.TypeSpec{
 					Type: "string",
 				},
 			},
 			"lambda": {
-				Description: "Lambda Function to attach to the Target Group. Exactly 1 of " +
-					"[instance], [instanceId], [lambda] or [lambdaArn] must be " +
-					"provided.",
+				Description: "Lambda Function to attach to the Target Group.",
 				TypeSpec: schema.TypeSpec{
 					Ref: packageRef(awsSpec, "/resources/aws:lambda%2ffunction:Function"),
 				},
@@ -210,21 +327,67 @@ func targetGroupAttachment(awsSpec schema.PackageSpec) schema.ResourceSpec {
 				},
 			},
 			"lambdaArn": {
-				Description: "ARN of a Lambda Function to attach to the Target Group. " +
-					"Exactly 1 of [instance], [instanceId], [lambda] or [lambdaArn] " +
-					"must be provided.",
+				Description: "ARN of a Lambda Function to attach to the Target Group.",
+				TypeSpec: schema.TypeSpec{
+					Type: "string",
+				},
+			},
+			"ipAddress": {
+				Description: "IP address to attach to a Target Group of the `ip` target type, e.g. the " +
+					"address of an ECS task or of a host in a peered VPC or on premises.",
+				TypeSpec: schema.TypeSpec{
+					Type: "string",
+				},
+			},
+			"availabilityZone": {
+				Description: "Availability Zone of the IP address. Set to `all` when the IP address is " +
+					"outside of the VPC of the Target Group. Can only be specified with [ipAddress].",
+				TypeSpec: schema.TypeSpec{
+					Type: "string",
+				},
+			},
+			"autoScalingGroup": {
+				Description: "Auto Scaling Group whose instances are attached to the Target Group.",
+				TypeSpec:    awsResource(awsSpec, "aws:autoscaling/group:Group"),
+			},
+			"autoScalingGroupName": {
+				Description: "Name of an Auto Scaling Group whose instances are attached to the " +
+					"Target Group.",
 				TypeSpec: schema.TypeSpec{
 					Type: "string",
 				},
 			},
+			"loadBalancer": {
+				Description: "Application Load Balancer to attach to a Target Group of the `alb` " +
+					"target type of a Network Load Balancer.",
+				TypeSpec: awsResource(awsSpec, "aws:lb/loadBalancer:LoadBalancer"),
+			},
+			"loadBalancerArn": {
+				Description: "ARN of an Application Load Balancer to attach to a Target Group of the " +
+					"`alb` target type of a Network Load Balancer.",
+				TypeSpec: schema.TypeSpec{
+					Type: "string",
+				},
+			},
+			"port": {
+				Description: "Port on which the target receives traffic. Defaults to the port of the " +
+					"Target Group. Can't be specified with [autoScalingGroup] or [autoScalingGroupName].",
+				TypeSpec: schema.TypeSpec{
+					Type: "integer",
+				},
+			},
 		},
 		ObjectTypeSpec: schema.ObjectTypeSpec{
 			Type: "object",
-			Description: "Attach an EC2 instance or Lambda to a Load Balancer. This " +
-				"will create required permissions if attaching to a Lambda Function.",
+			Description: "Attach an EC2 instance, Lambda, IP address, Auto Scaling Group or Application " +
+				"Load Balancer to a Load Balancer. This will create required permissions if attaching " +
+				"to a Lambda Function.\n\nExactly 1 of [instance], [instanceId], [lambda], [lambdaArn], " +
+				"[ipAddress], [autoScalingGroup], [autoScalingGroupName], [loadBalancer] or " +
+				"[loadBalancerArn] must be provided. Auto Scaling Groups are attached with an " +
+				"[autoScalingAttachment] instead of a [targetGroupAttachment].",
 			Properties: map[string]schema.PropertySpec{
 				"targetGroupAttachment": {
-					Description: "Underlying Target Group Attachment resource",
+					Description: "Underlying Target Group Attachment resource, unless attaching an Auto Scaling Group",
 					TypeSpec: schema.TypeSpec{
 						Ref: packageRef(awsSpec, "/resources/aws:lb%2ftargetGroupAttachment:TargetGroupAttachment"),
 					},
@@ -234,6 +397,10 @@ func targetGroupAttachment(awsSpec schema.PackageSpec) schema.ResourceSpec {
 						}),
 					},
 				},
+				"autoScalingAttachment": {
+					Description: "Auto Scaling Attachment resource, if attaching an Auto Scaling Group",
+					TypeSpec:    awsResource(awsSpec, "aws:autoscaling/attachment:Attachment"),
+				},
 				"lambdaPermission": {
 					Description: "Auto-created Lambda permission, if targeting a Lambda function",
 					TypeSpec: schema.TypeSpec{
@@ -241,7 +408,6 @@ func targetGroupAttachment(awsSpec schema.PackageSpec) schema.ResourceSpec {
 					},
 				},
 			},
-			Required: []string{"targetGroupAttachment"},
 		},
 	}
 }
@@ -250,6 +416,17 @@ func lbListener(awsSpec schema.PackageSpec) schema.ComplexTypeSpec {
 	spec := awsSpec.Resources["aws:lb/listener:Listener"]
 	properties := renameAwsPropertiesRefs(awsSpec, spec.InputProperties)
 	delete(properties, "loadBalancerArn")
+	properties["rules"] = listenerRulesProperty()
+	properties["targetGroup"] = listenerTargetGroupProperty()
+	properties["tls"] = schema.PropertySpec{
+		Description: "Terminate TLS on the listener of a network load balancer. Sets the " +
+			"protocol to `TLS` and the port to 443 by default. Only supported by network load " +
+			"balancers.",
+		TypeSpec: schema.TypeSpec{
+			Ref:   "#/types/awsx:lb:ListenerTls",
+			Plain: true,
+		},
+	}
 
 	return schema.ComplexTypeSpec{
 		ObjectTypeSpec: schema.ObjectTypeSpec{
@@ -260,6 +437,244 @@ func lbListener(awsSpec schema.PackageSpec) schema.ComplexTypeSpec {
 	}
 }
 
+func applicationLoadBalancerHttps() schema.ComplexTypeSpec {
+	return schema.ComplexTypeSpec{
+		ObjectTypeSpec: schema.ObjectTypeSpec{
+			Type:        "object",
+			Description: "HTTPS configuration of an application load balancer.",
+			Properties: map[string]schema.PropertySpec{
+				"domainName": {
+					Description: "Domain name to serve. Used for the certificate and the alias record.",
+					TypeSpec: schema.TypeSpec{
+						Type: "string",
+					},
+				},
+				"zoneId": {
+					Description: "ID of the Route 53 hosted zone of the domain name. The certificate " +
+						"validation and alias records are created in this zone.",
+					TypeSpec: schema.TypeSpec{
+						Type: "string",
+					},
+				},
+				"sslPolicy": {
+					Description: "Security policy of the HTTPS listener. Defaults to " +
+						"`ELBSecurityPolicy-TLS13-1-2-2021-06`, which supports TLS 1.2 and 1.3.",
+					TypeSpec: schema.TypeSpec{
+						Type: "string",
+					},
+				},
+				"rules":       listenerRulesProperty(),
+				"targetGroup": listenerTargetGroupProperty(),
+			},
+			Required: []string{"domainName", "zoneId"},
+		},
+	}
+}
+
+func listenerTargetGroupProperty() schema.PropertySpec {
+	return schema.PropertySpec{
+		Description: "Name of the target group or weighted forward of the load balancer to " +
+			"forward requests to by default. Defaults to the default target group. Cannot be " +
+			"used in combination with [defaultActions].",
+		TypeSpec: schema.TypeSpec{
+			Type:  "string",
+			Plain: true,
+		},
+	}
+}
+
+func lbAccessLogs() schema.ComplexTypeSpec {
+	return schema.ComplexTypeSpec{
+		ObjectTypeSpec: schema.ObjectTypeSpec{
+			Type:        "object",
+			Description: "Access logs of a load balancer, written to a bucket with default setup.",
+			Properties: map[string]schema.PropertySpec{
+				"existing": {
+					Description: "Identity of an existing bucket to use. The bucket policy must " +
+						"already allow the load balancer to deliver logs. Cannot be used in " +
+						"combination with `args`.",
+					TypeSpec: schema.TypeSpec{
+						Ref:   "#/types/awsx:awsx:ExistingBucket",
+						Plain: true,
+					},
+				},
+				"args": {
+					Description: "Arguments to use instead of the default values during creation.",
+					TypeSpec: schema.TypeSpec{
+						Ref:   "#/types/awsx:awsx:Bucket",
+						Plain: true,
+					},
+				},
+				"prefix": {
+					Description: "Prefix of the keys the logs are written to. " +
+						"Logs are written to the root of the bucket if not specified.",
+					TypeSpec: schema.TypeSpec{
+						Type: "string",
+					},
+				},
+			},
+		},
+	}
+}
+
+func lbWebAclPreset() schema.ComplexTypeSpec {
+	return schema.ComplexTypeSpec{
+		ObjectTypeSpec: schema.ObjectTypeSpec{
+			Type:        "string",
+			Description: "A preset of AWS managed rule groups for a WAF web ACL.",
+		},
+		Enum: []schema.EnumValueSpec{
+			{
+				Name:  "Baseline",
+				Value: "baseline",
+				Description: "The core rule set, known bad inputs and Amazon IP reputation list " +
+					"rule groups.",
+			},
+			{
+				Name:        "BaselineWithSqlDatabase",
+				Value:       "baseline-sql-database",
+				Description: "The baseline rule groups and the SQL database rule group.",
+			},
+			{
+				Name:        "BaselineWithLinux",
+				Value:       "baseline-linux",
+				Description: "The baseline rule groups and the Linux and POSIX operating system rule groups.",
+			},
+		},
+	}
+}
+
+func lbWeightedForward() schema.ComplexTypeSpec {
+	return schema.ComplexTypeSpec{
+		ObjectTypeSpec: schema.ObjectTypeSpec{
+			Type:        "object",
+			Description: "Forward action splitting requests across target groups by weight.",
+			Properties: map[string]schema.PropertySpec{
+				"weights": {
+					Description: "Weights of the target groups to forward to, keyed by target " +
+						"group name. Each weight is between 0 and 999.",
+					TypeSpec: schema.TypeSpec{
+						Type:                 "object",
+						Plain:                true,
+						AdditionalProperties: &schema.TypeSpec{Type: "integer"},
+					},
+				},
+				"stickinessDuration": {
+					Description: "Route requests of a client to the same target group for this " +
+						"many seconds, between 1 and 604800. Stickiness is disabled if not specified.",
+					TypeSpec: schema.TypeSpec{
+						Type: "integer",
+					},
+				},
+			},
+			Required: []string{"weights"},
+		},
+	}
+}
+
+func listenerRulesProperty() schema.PropertySpec {
+	return schema.PropertySpec{
+		Description: "Rules to route requests of the listener based on their host, path, " +
+			"headers or query string. Only supported by application load balancers.",
+		TypeSpec: schema.TypeSpec{
+			Type:  "array",
+			Plain: true,
+			Items: &schema.TypeSpec{
+				Ref:   "#/types/awsx:lb:ListenerRule",
+				Plain: true,
+			},
+		},
+	}
+}
+
+func lbListenerRule() schema.ComplexTypeSpec {
+	stringArray := schema.TypeSpec{
+		Type:  "array",
+		Items: &schema.TypeSpec{Type: "string"},
+	}
+	return schema.ComplexTypeSpec{
+		ObjectTypeSpec: schema.ObjectTypeSpec{
+			Type: "object",
+			Description: "A rule of a listener. Requests matching all of the conditions of the rule " +
+				"are handled by its action. At least one condition must be specified.",
+			Properties: map[string]schema.PropertySpec{
+				"priority": {
+					Description: "Priority of the rule between 1 and 50000. Rules are evaluated in " +
+						"order of priority, lowest first. Defaults to the next available priority.",
+					TypeSpec: schema.TypeSpec{
+						Type: "integer",
+					},
+				},
+				"hostHeaders": {
+					Description: "Host header patterns to match, e.g. `api.example.com` or " +
+						"`*.example.com`.",
+					TypeSpec: stringArray,
+				},
+				"pathPatterns": {
+					Description: "Path patterns to match, e.g. `/api/*`.",
+					TypeSpec:    stringArray,
+				},
+				"httpHeaders": {
+					Description: "HTTP headers to match, keyed by header name. A header matches " +
+						"if its value matches any of the given patterns.",
+					TypeSpec: schema.TypeSpec{
+						Type:                 "object",
+						AdditionalProperties: &stringArray,
+					},
+				},
+				"queryStrings": {
+					Description: "Query string parameters to match, keyed by parameter name. " +
+						"Requests must match all of the parameters.",
+					TypeSpec: schema.TypeSpec{
+						Type:                 "object",
+						AdditionalProperties: &schema.TypeSpec{Type: "string"},
+					},
+				},
+				"action": {
+					Description: "The action to take for matching requests.",
+					TypeSpec: schema.TypeSpec{
+						Ref:   "#/types/awsx:lb:ListenerRuleAction",
+						Plain: true,
+					},
+				},
+			},
+			Required: []string{"action"},
+		},
+	}
+}
+
+func lbListenerRuleAction(awsSpec schema.PackageSpec) schema.ComplexTypeSpec {
+	return schema.ComplexTypeSpec{
+		ObjectTypeSpec: schema.ObjectTypeSpec{
+			Type: "object",
+			Description: "Action of a listener rule. Exactly one of [targetGroup], [redirect] or " +
+				"[fixedResponse] must be specified.",
+			Properties: map[string]schema.PropertySpec{
+				"targetGroup": {
+					Description: "Name of the target group or weighted forward of the load " +
+						"balancer to forward requests to. The default target group is named `default`.",
+					TypeSpec: schema.TypeSpec{
+						Type:  "string",
+						Plain: true,
+					},
+				},
+				"redirect": {
+					Description: "Redirect requests to another URL.",
+					TypeSpec: schema.TypeSpec{
+						Ref: packageRef(awsSpec, "/types/aws:lb%2FListenerRuleActionRedirect:ListenerRuleActionRedirect"),
+					},
+				},
+				"fixedResponse": {
+					Description: "Return a fixed response.",
+					TypeSpec: schema.TypeSpec{
+						Ref: packageRef(awsSpec, "/types/aws:lb%2FListenerRuleActionFixedResponse:ListenerRuleActionFixedResponse"),
+					},
+				},
+			},
+		},
+	}
+}
+
 func lbTargetGroup(awsSpec schema.PackageSpec) schema.ComplexTypeSpec {
 	spec := awsSpec.Resources["aws:lb/targetGroup:TargetGroup"]
 	return schema.ComplexTypeSpec{
@@ -270,3 +685,315 @@ func lbTargetGroup(awsSpec schema.PackageSpec) schema.ComplexTypeSpec {
 		},
 	}
 }
+
+func lbListenerTls() schema.ComplexTypeSpec {
+	return schema.ComplexTypeSpec{
+		ObjectTypeSpec: schema.ObjectTypeSpec{
+			Type: "object",
+			Description: "TLS configuration of a network load balancer listener. Exactly one of " +
+				"[certificateArn] or [domainName] must be specified.",
+			Properties: map[string]schema.PropertySpec{
+				"certificateArn": {
+					Description: "ARN of the certificate to serve.",
+					TypeSpec: schema.TypeSpec{
+						Type: "string",
+					},
+				},
+				"domainName": {
+					Description: "Domain name to issue a DNS-validated ACM certificate for. Only one " +
+						"listener of a load balancer can issue a certificate.",
+					TypeSpec: schema.TypeSpec{
+						Type: "string",
+					},
+				},
+				"zoneId": {
+					Description: "ID of the Route 53 hosted zone of the domain name, in which the " +
+						"certificate validation record is created. Required with [domainName].",
+					TypeSpec: schema.TypeSpec{
+						Type: "string",
+					},
+				},
+				"alpnPolicy": {
+					Description: "Application-Layer Protocol Negotiation (ALPN) policy, e.g. " +
+						"`HTTP2Preferred` or `HTTP2Only` to serve gRPC.",
+					TypeSpec: schema.TypeSpec{
+						Type: "string",
+					},
+				},
+				"sslPolicy": {
+					Description: "Security policy of the listener. Defaults to " +
+						"`ELBSecurityPolicy-TLS13-1-2-2021-06`, which supports TLS 1.2 and 1.3.",
+					TypeSpec: schema.TypeSpec{
+						Type: "string",
+					},
+				},
+			},
+		},
+	}
+}
+
+func gatewayLoadBalancer(awsSpec schema.PackageSpec) schema.ResourceSpec {
+	inputProperties := map[string]schema.PropertySpec{
+		"subnetIds": {
+			Description: "The subnets of the load balancer, in which the appliances are running.",
+			TypeSpec: schema.TypeSpec{
+				Type: "array",
+				Items: &schema.TypeSpec{
+					Type: "string",
+				},
+			},
+		},
+		"endpoints": {
+			Description: "Gateway load balancer endpoints to create, usually one per availability " +
+				"zone. Traffic of the route tables of an endpoint is sent through the appliances.",
+			TypeSpec: schema.TypeSpec{
+				Type:  "array",
+				Plain: true,
+				Items: &schema.TypeSpec{
+					Ref:   "#/types/awsx:lb:GatewayLoadBalancerEndpoint",
+					Plain: true,
+				},
+			},
+		},
+		"targetGroup": {
+			Description: "Arguments to use instead of the default values of the GENEVE target " +
+				"group the appliances are registered with.",
+			TypeSpec: schema.TypeSpec{
+				Ref:   "#/types/awsx:lb:TargetGroup",
+				Plain: true,
+			},
+		},
+		"allowedPrincipals": {
+			Description: "ARNs of the principals allowed to create endpoints of the endpoint " +
+				"service, e.g. other accounts inspecting their traffic.",
+			TypeSpec: schema.TypeSpec{
+				Type: "array",
+				Items: &schema.TypeSpec{
+					Type: "string",
+				},
+			},
+		},
+		"enableCrossZoneLoadBalancing": {
+			Description: "Whether to distribute traffic across the appliances of all " +
+				"availability zones. Defaults to `false`.",
+			TypeSpec: schema.TypeSpec{
+				Type: "boolean",
+			},
+		},
+		"region": {
+			Description: "Region where the load balancer and its endpoints are managed. " +
+				"Defaults to the region set in the provider configuration.",
+			TypeSpec: schema.TypeSpec{
+				Type: "string",
+			},
+		},
+		"tags": {
+			Description: "Key-value map of resource tags.",
+			TypeSpec: schema.TypeSpec{
+				Type:                 "object",
+				AdditionalProperties: &schema.TypeSpec{Type: "string"},
+			},
+		},
+	}
+
+	return schema.ResourceSpec{
+		IsComponent: true,
+		ObjectTypeSpec: schema.ObjectTypeSpec{
+			Type: "object",
+			Description: "Provides a Gateway Load Balancer to insert third-party appliances, e.g. " +
+				"firewalls, into the traffic of a VPC. Creates the load balancer with a GENEVE " +
+				"target group, a VPC endpoint service and gateway load balancer endpoints, and " +
+				"routes the traffic of the given route tables through the endpoints.",
+			Properties: map[string]schema.PropertySpec{
+				"loadBalancer": {
+					Description: "Underlying Load Balancer resource",
+					TypeSpec:    awsResource(awsSpec, "aws:lb/loadBalancer:LoadBalancer"),
+				},
+				"targetGroup": {
+					Description: "GENEVE target group to register the appliances with",
+					TypeSpec:    awsResource(awsSpec, "aws:lb/targetGroup:TargetGroup"),
+				},
+				"listener": {
+					Description: "Listener forwarding all traffic to the target group",
+					TypeSpec:    awsResource(awsSpec, "aws:lb/listener:Listener"),
+				},
+				"endpointService": {
+					Description: "VPC endpoint service of the load balancer",
+					TypeSpec:    awsResource(awsSpec, "aws:ec2/vpcEndpointService:VpcEndpointService"),
+				},
+				"endpoints": {
+					Description: "Gateway load balancer endpoints, in the order of [endpoints]",
+					TypeSpec:    arrayOfAwsResource(awsSpec, "aws:ec2/vpcEndpoint:VpcEndpoint"),
+				},
+				"routes": {
+					Description: "Routes sending traffic through the endpoints",
+					TypeSpec:    arrayOfAwsResource(awsSpec, "aws:ec2/route:Route"),
+				},
+			},
+			Required: []string{
+				"loadBalancer",
+				"targetGroup",
+				"listener",
+				"endpointService",
+				"endpoints",
+				"routes",
+			},
+		},
+		InputProperties: inputProperties,
+		RequiredInputs:  []string{"subnetIds"},
+	}
+}
+
+func lambdaTarget(awsSpec schema.PackageSpec) schema.ResourceSpec {
+	stringArray := schema.TypeSpec{
+		Type:  "array",
+		Items: &schema.TypeSpec{Type: "string"},
+	}
+	inputProperties := map[string]schema.PropertySpec{
+		"listenerArn": {
+			Description: "ARN of the application load balancer listener to add the rule to.",
+			TypeSpec: schema.TypeSpec{
+				Type: "string",
+			},
+		},
+		"lambda": {
+			Description: "Lambda Function to forward requests to. Exactly 1 of [lambda] or " +
+				"[lambdaArn] must be provided.",
+			TypeSpec: awsResource(awsSpec, "aws:lambda/function:Function"),
+			Language: map[string]schema.RawMessage{
+				"python": rawMessage(map[string]string{
+					"name": "function",
+				}),
+			},
+		},
+		"lambdaArn": {
+			Description: "ARN of a Lambda Function to forward requests to. Exactly 1 of [lambda] " +
+				"or [lambdaArn] must be provided.",
+			TypeSpec: schema.TypeSpec{
+				Type: "string",
+			},
+		},
+		"pathPatterns": {
+			Description: "Path patterns of the requests to forward to the function, e.g. " +
+				"`/api/*`. At least one of [pathPatterns] or [hostHeaders] must be specified.",
+			TypeSpec: stringArray,
+		},
+		"hostHeaders": {
+			Description: "Host header patterns of the requests to forward to the function, e.g. " +
+				"`api.example.com`. At least one of [pathPatterns] or [hostHeaders] must be specified.",
+			TypeSpec: stringArray,
+		},
+		"priority": {
+			Description: "Priority of the listener rule between 1 and 50000. Defaults to the next " +
+				"available priority.",
+			TypeSpec: schema.TypeSpec{
+				Type: "integer",
+			},
+		},
+		"healthCheck": {
+			Description: "Health check of the function. Health checks are disabled by default, " +
+				"as each check invokes the function.",
+			TypeSpec: schema.TypeSpec{
+				Ref: packageRef(awsSpec, "/types/aws:lb%2FTargetGroupHealthCheck:TargetGroupHealthCheck"),
+			},
+		},
+		"multiValueHeadersEnabled": {
+			Description: "Whether request and response headers and query string parameters with " +
+				"multiple values are passed to and from the function as arrays. Defaults to `false`.",
+			TypeSpec: schema.TypeSpec{
+				Type: "boolean",
+			},
+		},
+		"region": {
+			Description: "Region where the resources are managed. Defaults to the region set in " +
+				"the provider configuration.",
+			TypeSpec: schema.TypeSpec{
+				Type: "string",
+			},
+		},
+		"tags": {
+			Description: "Key-value map of resource tags.",
+			TypeSpec: schema.TypeSpec{
+				Type:                 "object",
+				AdditionalProperties: &schema.TypeSpec{Type: "string"},
+			},
+		},
+	}
+
+	return schema.ResourceSpec{
+		IsComponent: true,
+		ObjectTypeSpec: schema.ObjectTypeSpec{
+			Type: "object",
+			Description: "Forwards requests of an application load balancer listener matching a " +
+				"path or host pattern to a Lambda Function. Creates the target group, the " +
+				"permission allowing the load balancer to invoke the function, the attachment and " +
+				"the listener rule.",
+			Properties: map[string]schema.PropertySpec{
+				"targetGroup": {
+					Description: "Target group of the function",
+					TypeSpec:    awsResource(awsSpec, "aws:lb/targetGroup:TargetGroup"),
+				},
+				"lambdaPermission": {
+					Description: "Permission allowing the load balancer to invoke the function",
+					TypeSpec:    awsResource(awsSpec, "aws:lambda/permission:Permission"),
+				},
+				"targetGroupAttachment": {
+					Description: "Attachment of the function to the target group",
+					TypeSpec:    awsResource(awsSpec, "aws:lb/targetGroupAttachment:TargetGroupAttachment"),
+					Language: map[string]schema.RawMessage{
+						"csharp": rawMessage(map[string]string{
+							"name": "Attachment",
+						}),
+					},
+				},
+				"listenerRule": {
+					Description: "Listener rule forwarding the matching requests to the target group",
+					TypeSpec:    awsResource(awsSpec, "aws:lb/listenerRule:ListenerRule"),
+				},
+			},
+			Required: []string{
+				"targetGroup",
+				"lambdaPermission",
+				"targetGroupAttachment",
+				"listenerRule",
+			},
+		},
+		InputProperties: inputProperties,
+		RequiredInputs:  []string{"listenerArn"},
+	}
+}
+
+func gatewayLoadBalancerEndpoint() schema.ComplexTypeSpec {
+	return schema.ComplexTypeSpec{
+		ObjectTypeSpec: schema.ObjectTypeSpec{
+			Type:        "object",
+			Description: "A gateway load balancer endpoint and the routes sending traffic through it.",
+			Properties: map[string]schema.PropertySpec{
+				"subnetId": {
+					Description: "The subnet to create the endpoint in, e.g. one of the " +
+						"`publicSubnetIds` of an `awsx.ec2.Vpc`.",
+					TypeSpec: schema.TypeSpec{
+						Type: "string",
+					},
+				},
+				"routeTableIds": {
+					Description: "Route tables to send traffic to [destinationCidrBlock] through the " +
+						"endpoint, e.g. route tables of an `awsx.ec2.Vpc`. The route tables should be " +
+						"associated with subnets in the availability zone of the endpoint and must " +
+						"not already have a route to [destinationCidrBlock]. A route is created per " +
+						"route table, so the list itself must be known before the deployment.",
+					TypeSpec: plainArrayOfPulumiStrings(),
+				},
+				"destinationCidrBlock": {
+					Description: "Destination of the routes through the endpoint. Required with " +
+						"[routeTableIds]. Route tables with a default route, like the public route " +
+						"tables of an `awsx.ec2.Vpc`, can't also route `0.0.0.0/0` through the endpoint.",
+					TypeSpec: schema.TypeSpec{
+						Type: "string",
+					},
+				},
+			},
+			Required: []string{"subnetId"},
+		},
+	}
+}
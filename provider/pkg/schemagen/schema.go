@@ -124,6 +124,7 @@ func GenerateSchema(packageDir string) schema.PackageSpec {
 		generateS3(awsSpec),
 		generateEc2(awsSpec),
 		generateEcr(awsSpec, dockerSpec),
+		generateEfs(awsSpec),
 	)
 }
 